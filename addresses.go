@@ -0,0 +1,87 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpAddress struct {
+	address *computepb.Address
+}
+
+func (g gcpAddress) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "address":
+		// e.g. address:10.0.0.0/8, address=34.76.12.5
+		return t.evaluateIP(g.address.GetAddress())
+	case "addressType":
+		return t.evaluate(g.address.GetAddressType())
+	case "creationTimestamp":
+		return t.evaluate(g.address.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.address.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.address.GetId(), 10))
+	case "ipVersion":
+		return t.evaluate(g.address.GetIpVersion())
+	case "ipv6EndpointType":
+		return t.evaluate(g.address.GetIpv6EndpointType())
+	case "kind":
+		return t.evaluate(g.address.GetKind())
+	case "labelFingerprint":
+		return t.evaluate(g.address.GetLabelFingerprint())
+	case "labels":
+		return t.evaluateLabels(g.address.GetLabels())
+	case "name":
+		return t.evaluate(g.address.GetName())
+	case "network":
+		return t.evaluate(g.address.GetNetwork())
+	case "networkTier":
+		return t.evaluate(g.address.GetNetworkTier())
+	case "prefixLength":
+		return t.evaluate(strconv.FormatInt(int64(g.address.GetPrefixLength()), 10))
+	case "purpose":
+		return t.evaluate(g.address.GetPurpose())
+	case "region":
+		return t.evaluate(g.address.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.address.GetSelfLink())
+	case "status":
+		return t.evaluate(g.address.GetStatus())
+	case "subnetwork":
+		return t.evaluate(g.address.GetSubnetwork())
+	case "users":
+		// e.g. users:*, -users:*
+		return t.evaluateRepeated(g.address.GetUsers())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterAddresses filters the given addresses according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/addresses/aggregatedList
+//  2. The address key matches CIDR ranges e.g. address:10.0.0.0/8
+func FilterAddresses(addresses []*computepb.Address, gcpFilter string) ([]*computepb.Address, error) {
+	return filterResources(addresses, gcpFilter, func(address *computepb.Address) gcpAddress {
+		return gcpAddress{address: address}
+	})
+}
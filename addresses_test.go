@@ -0,0 +1,185 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type addressesArray []*computepb.Address
+
+func (a addressesArray) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, address := range a {
+		sb.WriteString(address.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterAddresses(t *testing.T) {
+	addresses := addressesArray{
+		{
+			Address:           toStringPtr("34.76.12.5"),
+			AddressType:       toStringPtr("EXTERNAL"),
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#address"),
+			Name:              toStringPtr("orphaned-ip"),
+			NetworkTier:       toStringPtr("PREMIUM"),
+			Region:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status:            toStringPtr("RESERVED"),
+			Labels: map[string]string{
+				"team": "platform",
+			},
+		},
+		{
+			Address:           toStringPtr("35.190.3.44"),
+			AddressType:       toStringPtr("EXTERNAL"),
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#address"),
+			Name:              toStringPtr("lb-ip"),
+			NetworkTier:       toStringPtr("STANDARD"),
+			Region:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-east1"),
+			Status:            toStringPtr("IN_USE"),
+			Users: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-east1/forwardingRules/testlbip",
+			},
+		},
+		{
+			Address:           toStringPtr("10.132.0.20"),
+			AddressType:       toStringPtr("INTERNAL"),
+			CreationTimestamp: toStringPtr("2024-02-11T01:12:21.102-08:00"),
+			Id:                toUint64Ptr(6123462547295123412),
+			Kind:              toStringPtr("compute#address"),
+			Name:              toStringPtr("db-ip"),
+			Purpose:           toStringPtr("GCE_ENDPOINT"),
+			Region:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status:            toStringPtr("RESERVED"),
+			Subnetwork:        toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/subnetworks/default"),
+			Labels: map[string]string{
+				"team": "db",
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantAddresses addressesArray
+		wantErr       bool
+	}{
+		{
+			name: "Orphaned reserved IPs",
+			args: args{
+				gcpFilter: `status=RESERVED AND -users:* addressType=EXTERNAL`,
+			},
+			wantAddresses: addressesArray{
+				addresses[0],
+			},
+		},
+		{
+			name: "CIDR ranges",
+			args: args{
+				gcpFilter: `address:(10.0.0.0/8 "192.168.0.0/16") OR address:35.190.0.0/16`,
+			},
+			wantAddresses: addressesArray{
+				addresses[1],
+				addresses[2],
+			},
+		},
+		{
+			name: "IP comparisons",
+			args: args{
+				gcpFilter: `address!=10.0.0.0/8 AND address<35.0.0.0 address=34.76.12.5`,
+			},
+			wantAddresses: addressesArray{
+				addresses[0],
+			},
+		},
+		{
+			name: "IP regular expressions",
+			args: args{
+				gcpFilter: `address~^10\.132\. OR address~34.76.12.5`,
+			},
+			wantAddresses: addressesArray{
+				addresses[0],
+				addresses[2],
+			},
+		},
+		{
+			name: "IP not equal to any of a list",
+			args: args{
+				gcpFilter: `address!=(34.76.12.5 35.190.3.44)`,
+			},
+			wantAddresses: addressesArray{
+				addresses[2],
+			},
+		},
+		{
+			name: "IP not in any of the CIDR ranges",
+			args: args{
+				gcpFilter: `address!=(10.0.0.0/8 "35.190.0.0/16")`,
+			},
+			wantAddresses: addressesArray{
+				addresses[0],
+			},
+		},
+		{
+			name: "Wildcards, users, labels",
+			args: args{
+				gcpFilter: `address:35.* users:*testlbip OR labels.team:db networkTier:*`,
+			},
+			wantAddresses: addressesArray{
+				addresses[1],
+			},
+		},
+		{
+			name: "Region and labels",
+			args: args{
+				gcpFilter: `region:*europe-west1 labels.team:(platform db) purpose:*`,
+			},
+			wantAddresses: addressesArray{
+				addresses[2],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAddresses, err := FilterAddresses(addresses, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterAddresses() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotAddressesArray := addressesArray(gotAddresses)
+			if !reflect.DeepEqual(gotAddressesArray, tt.wantAddresses) {
+				t.Errorf("FilterAddresses(): \"%v\". want: \"%v\"", gotAddressesArray, tt.wantAddresses)
+			}
+			t.Log(gotAddressesArray)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"reflect"
+
+	"cloud.google.com/go/asset/apiv1/assetpb"
+)
+
+type gcpAsset struct {
+	asset *assetpb.Asset
+}
+
+func (g gcpAsset) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "ancestors":
+		return t.evaluateRepeated(g.asset.GetAncestors())
+	case "assetType":
+		return t.evaluate(g.asset.GetAssetType())
+	case "iamPolicy":
+		// Existence check e.g. iamPolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return g.asset.GetIamPolicy() != nil, nil
+		}
+		return filterIAMPolicy(t.descend(), g.asset.GetIamPolicy())
+	case "name":
+		return t.evaluate(g.asset.GetName())
+	case "resource":
+		return filterAssetResource(t.descend(), g.asset.GetResource())
+	case "updateTime":
+		return t.evaluateProtoTimestamp(g.asset.GetUpdateTime())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterAssetResource(t term, resource *assetpb.Resource) (bool, error) {
+	switch t.Key {
+	case "data":
+		// The resource's JSON representation e.g. resource.data.status=RUNNING, resource.data.labels.env:prod
+		return filterFieldValue(t, reflect.ValueOf(resource.GetData()))
+	case "discoveryDocumentUri":
+		return t.evaluate(resource.GetDiscoveryDocumentUri())
+	case "discoveryName":
+		return t.evaluate(resource.GetDiscoveryName())
+	case "location":
+		return t.evaluate(resource.GetLocation())
+	case "parent":
+		return t.evaluate(resource.GetParent())
+	case "resourceUrl":
+		return t.evaluate(resource.GetResourceUrl())
+	case "version":
+		return t.evaluate(resource.GetVersion())
+	default:
+		return false, fmt.Errorf("unknown resource key %v", t.Key)
+	}
+}
+
+// FilterAssets filters the given Cloud Asset Inventory assets according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/listAssets#Asset
+//  2. resource.data is the JSON representation of the resource therefore its keys depend on the assetType
+//     e.g. assetType=compute.googleapis.com/Instance resource.data.machineType:*e2-*
+func FilterAssets(assets []*assetpb.Asset, gcpFilter string) ([]*assetpb.Asset, error) {
+	return filterResources(assets, gcpFilter, func(asset *assetpb.Asset) gcpAsset {
+		return gcpAsset{asset: asset}
+	})
+}
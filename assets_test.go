@@ -0,0 +1,180 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/asset/apiv1/assetpb"
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type assetsArray []*assetpb.Asset
+
+func (a assetsArray) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, asset := range a {
+		sb.WriteString(asset.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterAssets(t *testing.T) {
+	newStruct := func(fields map[string]any) *structpb.Struct {
+		data, err := structpb.NewStruct(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	assets := assetsArray{
+		{
+			Name:       "//compute.googleapis.com/projects/appgate-dev/zones/europe-west1-b/instances/bastion",
+			AssetType:  "compute.googleapis.com/Instance",
+			Ancestors:  []string{"projects/82699087620", "organizations/448593862441"},
+			UpdateTime: timestamppb.New(time.Date(2023, 12, 1, 11, 52, 49, 0, time.UTC)),
+			Resource: &assetpb.Resource{
+				Version:  "v1",
+				Location: "europe-west1-b",
+				Parent:   "//cloudresourcemanager.googleapis.com/projects/82699087620",
+				Data: newStruct(map[string]any{
+					"name":        "bastion",
+					"machineType": "https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/machineTypes/e2-small",
+					"status":      "RUNNING",
+					"labels": map[string]any{
+						"env": "prod",
+					},
+					"networkInterfaces": []any{
+						map[string]any{
+							"networkIP": "10.0.0.5",
+						},
+					},
+					"canIpForward": false,
+				}),
+			},
+		},
+		{
+			Name:       "//storage.googleapis.com/appgate-dev-logs",
+			AssetType:  "storage.googleapis.com/Bucket",
+			Ancestors:  []string{"projects/76499083636", "folders/456", "organizations/448593862441"},
+			UpdateTime: timestamppb.New(time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)),
+			Resource: &assetpb.Resource{
+				Version:  "v1",
+				Location: "eu",
+				Data: newStruct(map[string]any{
+					"name":         "appgate-dev-logs",
+					"storageClass": "STANDARD",
+					"iamConfiguration": map[string]any{
+						"uniformBucketLevelAccess": map[string]any{
+							"enabled": true,
+						},
+					},
+				}),
+			},
+			IamPolicy: &iampb.Policy{
+				Bindings: []*iampb.Binding{
+					{
+						Role:    "roles/storage.objectViewer",
+						Members: []string{"allUsers"},
+					},
+				},
+			},
+		},
+		{
+			Name:      "//cloudresourcemanager.googleapis.com/projects/82699087621",
+			AssetType: "cloudresourcemanager.googleapis.com/Project",
+			Ancestors: []string{"projects/82699087621", "folders/789", "organizations/448593862441"},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAssets assetsArray
+		wantErr    bool
+	}{
+		{
+			name: "Resource data",
+			args: args{
+				gcpFilter: `assetType=compute.googleapis.com/Instance AND resource.data.machineType:*e2-* AND resource.data.labels.env=prod`,
+			},
+			wantAssets: assetsArray{
+				assets[0],
+			},
+		},
+		{
+			name: "Resource data repeated and booleans",
+			args: args{
+				gcpFilter: `resource.data.networkInterfaces.networkIP:10.0.0.5 OR resource.data.iamConfiguration.uniformBucketLevelAccess.enabled=true`,
+			},
+			wantAssets: assetsArray{
+				assets[0],
+				assets[1],
+			},
+		},
+		{
+			name: "Ancestors and missing data",
+			args: args{
+				gcpFilter: `ancestors:folders/* AND NOT resource.data:*`,
+			},
+			wantAssets: assetsArray{
+				assets[2],
+			},
+		},
+		{
+			name: "Update time and IAM policy",
+			args: args{
+				gcpFilter: `updateTime>="2024-01-01T00:00:00Z" AND iamPolicy.bindings.members:allUsers`,
+			},
+			wantAssets: assetsArray{
+				assets[1],
+			},
+		},
+		{
+			name: "Unknown resource key",
+			args: args{
+				gcpFilter: `resource.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAssets, err := FilterAssets(assets, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterAssets() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotAssetsArray := assetsArray(gotAssets)
+			if !reflect.DeepEqual(gotAssetsArray, tt.wantAssets) {
+				t.Errorf("FilterAssets(): \"%v\". want: \"%v\"", gotAssetsArray, tt.wantAssets)
+			}
+			t.Log(gotAssetsArray)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpBackendService struct {
+	backendService *computepb.BackendService
+}
+
+func (g gcpBackendService) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "affinityCookieTtlSec":
+		return t.evaluate(strconv.FormatInt(int64(g.backendService.GetAffinityCookieTtlSec()), 10))
+	case "backends":
+		// e.g. backends.group:*instanceGroups/web, backends.balancingMode=UTILIZATION
+		return filterRepeated(t, g.backendService.GetBackends(), filterBackend)
+	case "compressionMode":
+		return t.evaluate(g.backendService.GetCompressionMode())
+	case "connectionDraining":
+		const drainingTimeoutSecKey = "drainingTimeoutSec"
+		if drainingTimeoutSecKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatInt(int64(g.backendService.GetConnectionDraining().GetDrainingTimeoutSec()), 10))
+		}
+		return false, fmt.Errorf("unknown connectionDraining key %v", t.AttributeKey)
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.backendService.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.backendService.GetDescription())
+	case "edgeSecurityPolicy":
+		return t.evaluate(g.backendService.GetEdgeSecurityPolicy())
+	case "enableCDN":
+		return t.evaluate(strconv.FormatBool(g.backendService.GetEnableCDN()))
+	case "fingerprint":
+		return t.evaluate(g.backendService.GetFingerprint())
+	case "healthChecks":
+		return t.evaluateRepeated(g.backendService.GetHealthChecks())
+	case "iap":
+		const enabledKey = "enabled"
+		if enabledKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatBool(g.backendService.GetIap().GetEnabled()))
+		}
+		return false, fmt.Errorf("unknown iap key %v", t.AttributeKey)
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.backendService.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.backendService.GetKind())
+	case "loadBalancingScheme":
+		return t.evaluate(g.backendService.GetLoadBalancingScheme())
+	case "localityLbPolicy":
+		return t.evaluate(g.backendService.GetLocalityLbPolicy())
+	case "logConfig":
+		switch t.AttributeKey {
+		case "enable":
+			return t.evaluate(strconv.FormatBool(g.backendService.GetLogConfig().GetEnable()))
+		case "sampleRate":
+			return t.evaluate(fmt.Sprint(g.backendService.GetLogConfig().GetSampleRate()))
+		default:
+			return false, fmt.Errorf("unknown logConfig key %v", t.AttributeKey)
+		}
+	case "name":
+		return t.evaluate(g.backendService.GetName())
+	case "network":
+		return t.evaluate(g.backendService.GetNetwork())
+	case "port":
+		return t.evaluate(strconv.FormatInt(int64(g.backendService.GetPort()), 10))
+	case "portName":
+		return t.evaluate(g.backendService.GetPortName())
+	case "protocol":
+		return t.evaluate(g.backendService.GetProtocol())
+	case "region":
+		return t.evaluate(g.backendService.GetRegion())
+	case "securityPolicy":
+		return t.evaluate(g.backendService.GetSecurityPolicy())
+	case "selfLink":
+		return t.evaluate(g.backendService.GetSelfLink())
+	case "sessionAffinity":
+		return t.evaluate(g.backendService.GetSessionAffinity())
+	case "timeoutSec":
+		return t.evaluate(strconv.FormatInt(int64(g.backendService.GetTimeoutSec()), 10))
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterBackend(t term, backend *computepb.Backend) (bool, error) {
+	switch t.Key {
+	case "balancingMode":
+		return t.evaluate(backend.GetBalancingMode())
+	case "capacityScaler":
+		return t.evaluate(fmt.Sprint(backend.GetCapacityScaler()))
+	case "description":
+		return t.evaluate(backend.GetDescription())
+	case "failover":
+		return t.evaluate(strconv.FormatBool(backend.GetFailover()))
+	case "group":
+		return t.evaluate(backend.GetGroup())
+	case "maxConnections":
+		return t.evaluate(strconv.FormatInt(int64(backend.GetMaxConnections()), 10))
+	case "maxRatePerInstance":
+		return t.evaluate(fmt.Sprint(backend.GetMaxRatePerInstance()))
+	case "maxUtilization":
+		return t.evaluate(fmt.Sprint(backend.GetMaxUtilization()))
+	default:
+		return false, fmt.Errorf("unknown backends key %v", t.Key)
+	}
+}
+
+// FilterBackendServices filters the given backend services according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/backendServices/aggregatedList
+func FilterBackendServices(backendServices []*computepb.BackendService, gcpFilter string) ([]*computepb.BackendService, error) {
+	return filterResources(backendServices, gcpFilter, func(backendService *computepb.BackendService) gcpBackendService {
+		return gcpBackendService{backendService: backendService}
+	})
+}
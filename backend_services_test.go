@@ -0,0 +1,161 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type backendServicesArray []*computepb.BackendService
+
+func (b backendServicesArray) String() string {
+	if len(b) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, backendService := range b {
+		sb.WriteString(backendService.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func toFloat32Ptr(v float32) *float32 {
+	return &v
+}
+
+func TestFilterBackendServices(t *testing.T) {
+	backendServices := backendServicesArray{
+		{
+			Backends: []*computepb.Backend{
+				{
+					BalancingMode:  toStringPtr("UTILIZATION"),
+					CapacityScaler: toFloat32Ptr(1),
+					Group:          toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/instanceGroups/web"),
+					MaxUtilization: toFloat32Ptr(0.8),
+				},
+			},
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			EnableCDN:         toBoolPtr(true),
+			HealthChecks: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/global/healthChecks/http-basic-check",
+			},
+			Id:                  toUint64Ptr(2123462547295123412),
+			Kind:                toStringPtr("compute#backendService"),
+			LoadBalancingScheme: toStringPtr("EXTERNAL_MANAGED"),
+			LogConfig: &computepb.BackendServiceLogConfig{
+				Enable:     toBoolPtr(true),
+				SampleRate: toFloat32Ptr(0.5),
+			},
+			Name:       toStringPtr("web-backend-service"),
+			Port:       toInt32Ptr(80),
+			PortName:   toStringPtr("http"),
+			Protocol:   toStringPtr("HTTP"),
+			TimeoutSec: toInt32Ptr(30),
+		},
+		{
+			Backends: []*computepb.Backend{
+				{
+					BalancingMode: toStringPtr("CONNECTION"),
+					Group:         toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/instanceGroups/udp-a"),
+				},
+				{
+					BalancingMode: toStringPtr("CONNECTION"),
+					Failover:      toBoolPtr(true),
+					Group:         toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-c/instanceGroups/udp-b"),
+				},
+			},
+			ConnectionDraining: &computepb.ConnectionDraining{
+				DrainingTimeoutSec: toInt32Ptr(300),
+			},
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			HealthChecks: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/healthChecks/tcp-check",
+			},
+			Id:                  toUint64Ptr(4123462547295123412),
+			Kind:                toStringPtr("compute#backendService"),
+			LoadBalancingScheme: toStringPtr("INTERNAL"),
+			Name:                toStringPtr("lbudp"),
+			Protocol:            toStringPtr("UDP"),
+			Region:              toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			SessionAffinity:     toStringPtr("CLIENT_IP"),
+			TimeoutSec:          toInt32Ptr(600),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                string
+		args                args
+		wantBackendServices backendServicesArray
+		wantErr             bool
+	}{
+		{
+			name: "Protocol, scheme, backends",
+			args: args{
+				gcpFilter: `protocol=HTTP loadBalancingScheme:EXTERNAL* backends.group:*instanceGroups/web backends.maxUtilization>=0.8`,
+			},
+			wantBackendServices: backendServicesArray{
+				backendServices[0],
+			},
+		},
+		{
+			name: "Health checks and timeouts",
+			args: args{
+				gcpFilter: `healthChecks:*tcp-check AND timeoutSec>60 connectionDraining.drainingTimeoutSec=300 backends.failover=true`,
+			},
+			wantBackendServices: backendServicesArray{
+				backendServices[1],
+			},
+		},
+		{
+			name: "Logging and CDN",
+			args: args{
+				gcpFilter: `logConfig.enable=true OR enableCDN=true OR -region:*`,
+			},
+			wantBackendServices: backendServicesArray{
+				backendServices[0],
+			},
+		},
+		{
+			name: "Unknown backends key",
+			args: args{
+				gcpFilter: `backends.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBackendServices, err := FilterBackendServices(backendServices, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterBackendServices() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotBackendServicesArray := backendServicesArray(gotBackendServices)
+			if !reflect.DeepEqual(gotBackendServicesArray, tt.wantBackendServices) {
+				t.Errorf("FilterBackendServices(): \"%v\". want: \"%v\"", gotBackendServicesArray, tt.wantBackendServices)
+			}
+			t.Log(gotBackendServicesArray)
+		})
+	}
+}
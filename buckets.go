@@ -0,0 +1,52 @@
+package gcloudfilter
+
+import (
+	"reflect"
+
+	"google.golang.org/api/storage/v1"
+)
+
+type gcpBucket struct {
+	bucket *storage.Bucket
+}
+
+func (g gcpBucket) filterTerm(t term) (bool, error) {
+	// The times are RFC3339 strings that are compared as times e.g. timeCreated<"2024-01-01T00:00:00Z"
+	const effectiveTimeKey = "effectiveTime"
+	switch t.Key {
+	case "autoclass":
+		const toggleTimeKey = "toggleTime"
+		if toggleTimeKey == t.AttributeKey && g.bucket.Autoclass != nil {
+			return t.evaluateTimestampString(g.bucket.Autoclass.ToggleTime)
+		}
+	case "iamConfiguration":
+		const lockedTimeKey = "uniformBucketLevelAccess.lockedTime"
+		if lockedTimeKey == t.AttributeKey && g.bucket.IamConfiguration != nil && g.bucket.IamConfiguration.UniformBucketLevelAccess != nil {
+			return t.evaluateTimestampString(g.bucket.IamConfiguration.UniformBucketLevelAccess.LockedTime)
+		}
+	case "retentionPolicy":
+		if effectiveTimeKey == t.AttributeKey && g.bucket.RetentionPolicy != nil {
+			return t.evaluateTimestampString(g.bucket.RetentionPolicy.EffectiveTime)
+		}
+	case "softDeletePolicy":
+		if effectiveTimeKey == t.AttributeKey && g.bucket.SoftDeletePolicy != nil {
+			return t.evaluateTimestampString(g.bucket.SoftDeletePolicy.EffectiveTime)
+		}
+	case "timeCreated":
+		return t.evaluateTimestampString(g.bucket.TimeCreated)
+	case "updated":
+		return t.evaluateTimestampString(g.bucket.Updated)
+	}
+	return filterField(t, reflect.ValueOf(g.bucket))
+}
+
+// FilterBuckets filters the given Cloud Storage buckets according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of the JSON API https://cloud.google.com/storage/docs/json_api/v1/buckets#resource
+//     e.g. iamConfiguration.uniformBucketLevelAccess.enabled, versioning.enabled, timeCreated
+//  2. retentionPolicy.retentionPeriod and softDeletePolicy.retentionDurationSeconds are in seconds
+func FilterBuckets(buckets []*storage.Bucket, gcpFilter string) ([]*storage.Bucket, error) {
+	return filterResources(buckets, gcpFilter, func(bucket *storage.Bucket) gcpBucket {
+		return gcpBucket{bucket: bucket}
+	})
+}
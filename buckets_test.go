@@ -0,0 +1,191 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/storage/v1"
+)
+
+type bucketsArray []*storage.Bucket
+
+func (b bucketsArray) String() string {
+	if len(b) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, bucket := range b {
+		sb.WriteString(bucket.Name + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterBuckets(t *testing.T) {
+	buckets := bucketsArray{
+		{
+			Name:         "appgate-dev-logs",
+			Location:     "EUROPE-WEST1",
+			LocationType: "region",
+			StorageClass: "STANDARD",
+			TimeCreated:  "2023-10-24T09:06:40.108Z",
+			IamConfiguration: &storage.BucketIamConfiguration{
+				UniformBucketLevelAccess: &storage.BucketIamConfigurationUniformBucketLevelAccess{
+					Enabled: true,
+				},
+				PublicAccessPrevention: "enforced",
+			},
+			Versioning: &storage.BucketVersioning{
+				Enabled: true,
+			},
+			RetentionPolicy: &storage.BucketRetentionPolicy{
+				EffectiveTime:   "2023-10-24T09:06:40.108Z",
+				RetentionPeriod: 2592000,
+				IsLocked:        true,
+			},
+			Labels: map[string]string{
+				"env": "dev",
+			},
+		},
+		{
+			Name:         "appgate-public-assets",
+			Location:     "EU",
+			LocationType: "multi-region",
+			StorageClass: "STANDARD",
+			TimeCreated:  "2024-02-01T12:00:00.000Z",
+			IamConfiguration: &storage.BucketIamConfiguration{
+				PublicAccessPrevention: "inherited",
+			},
+			Website: &storage.BucketWebsite{
+				MainPageSuffix: "index.html",
+			},
+		},
+		{
+			Name:         "appgate-archive",
+			Location:     "US",
+			LocationType: "multi-region",
+			StorageClass: "ARCHIVE",
+			TimeCreated:  "2021-05-03T08:30:00.000Z",
+			IamConfiguration: &storage.BucketIamConfiguration{
+				UniformBucketLevelAccess: &storage.BucketIamConfigurationUniformBucketLevelAccess{
+					Enabled: true,
+				},
+				PublicAccessPrevention: "enforced",
+			},
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantBuckets bucketsArray
+		wantErr     bool
+	}{
+		{
+			name: "Compliance",
+			args: args{
+				gcpFilter: `NOT iamConfiguration.uniformBucketLevelAccess.enabled=true OR iamConfiguration.publicAccessPrevention!=enforced`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[1],
+			},
+		},
+		{
+			name: "Location and storage class",
+			args: args{
+				gcpFilter: `locationType=multi-region AND storageClass:(ARCHIVE COLDLINE) AND location=us`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[2],
+			},
+		},
+		{
+			name: "Versioning and retention policy",
+			args: args{
+				gcpFilter: `versioning.enabled=true AND retentionPolicy.retentionPeriod>=2592000 AND retentionPolicy.isLocked=true`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[0],
+			},
+		},
+		{
+			name: "Retention policy existence",
+			args: args{
+				gcpFilter: `NOT retentionPolicy:* AND labels.env:*`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[2],
+			},
+		},
+		{
+			name: "Created time",
+			args: args{
+				gcpFilter: `timeCreated>="2023-01-01T00:00:00Z" AND timeCreated<"2024-01-01T00:00:00+01:00"`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[0],
+			},
+		},
+		{
+			name: "Retention policy effective time",
+			args: args{
+				gcpFilter: `retentionPolicy.effectiveTime>"2023-10-24T10:00:00+01:00" OR softDeletePolicy.effectiveTime:*`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[0],
+			},
+		},
+		{
+			name: "Website",
+			args: args{
+				gcpFilter: `website.mainPageSuffix:*`,
+			},
+			wantBuckets: bucketsArray{
+				buckets[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `iamConfiguration.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBuckets, err := FilterBuckets(buckets, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterBuckets() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotBucketsArray := bucketsArray(gotBuckets)
+			if !reflect.DeepEqual(gotBucketsArray, tt.wantBuckets) {
+				t.Errorf("FilterBuckets(): \"%v\". want: \"%v\"", gotBucketsArray, tt.wantBuckets)
+			}
+			t.Log(gotBucketsArray)
+		})
+	}
+}
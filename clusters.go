@@ -0,0 +1,147 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+type gcpCluster struct {
+	cluster *containerpb.Cluster
+}
+
+func (g gcpCluster) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "autopilot":
+		const enabledKey = "enabled"
+		if enabledKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatBool(g.cluster.GetAutopilot().GetEnabled()))
+		}
+		return false, fmt.Errorf("unknown autopilot key %v", t.AttributeKey)
+	case "clusterIpv4Cidr":
+		return t.evaluateCIDR(g.cluster.GetClusterIpv4Cidr())
+	case "createTime":
+		return t.evaluateTimestampString(g.cluster.GetCreateTime())
+	case "currentMasterVersion":
+		return t.evaluateVersion(g.cluster.GetCurrentMasterVersion())
+	case "currentNodeCount":
+		return t.evaluate(strconv.FormatInt(int64(g.cluster.GetCurrentNodeCount()), 10))
+	case "currentNodeVersion":
+		return t.evaluateVersion(g.cluster.GetCurrentNodeVersion())
+	case "description":
+		return t.evaluate(g.cluster.GetDescription())
+	case "endpoint":
+		return t.evaluateIP(g.cluster.GetEndpoint())
+	case "etag":
+		return t.evaluate(g.cluster.GetEtag())
+	case "expireTime":
+		return t.evaluateTimestampString(g.cluster.GetExpireTime())
+	case "id":
+		return t.evaluate(g.cluster.GetId())
+	case "initialClusterVersion":
+		return t.evaluateVersion(g.cluster.GetInitialClusterVersion())
+	case "initialNodeCount":
+		return t.evaluate(strconv.FormatInt(int64(g.cluster.GetInitialNodeCount()), 10))
+	case "instanceGroupUrls":
+		return t.evaluateRepeated(g.cluster.GetInstanceGroupUrls())
+	case "labelFingerprint":
+		return t.evaluate(g.cluster.GetLabelFingerprint())
+	case "location":
+		return t.evaluate(g.cluster.GetLocation())
+	case "locations":
+		return t.evaluateRepeated(g.cluster.GetLocations())
+	case "loggingService":
+		return t.evaluate(g.cluster.GetLoggingService())
+	case "masterAuthorizedNetworksConfig":
+		const enabledKey = "enabled"
+		if enabledKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatBool(g.cluster.GetMasterAuthorizedNetworksConfig().GetEnabled()))
+		}
+		return false, fmt.Errorf("unknown masterAuthorizedNetworksConfig key %v", t.AttributeKey)
+	case "monitoringService":
+		return t.evaluate(g.cluster.GetMonitoringService())
+	case "name":
+		return t.evaluate(g.cluster.GetName())
+	case "network":
+		return t.evaluate(g.cluster.GetNetwork())
+	case "nodeConfig":
+		return filterNodeConfig(t.descend(), g.cluster.GetNodeConfig())
+	case "nodePools":
+		// e.g. nodePools.config.machineType:n2-*, nodePools.version<1.28
+		return filterRepeated(t, g.cluster.GetNodePools(), filterNodePool)
+	case "privateClusterConfig":
+		privateClusterConfig := g.cluster.GetPrivateClusterConfig()
+		switch t.AttributeKey {
+		case "enablePrivateEndpoint":
+			return t.evaluate(strconv.FormatBool(privateClusterConfig.GetEnablePrivateEndpoint()))
+		case "enablePrivateNodes":
+			return t.evaluate(strconv.FormatBool(privateClusterConfig.GetEnablePrivateNodes()))
+		case "masterIpv4CidrBlock":
+			return t.evaluateCIDR(privateClusterConfig.GetMasterIpv4CidrBlock())
+		case "privateEndpoint":
+			return t.evaluateIP(privateClusterConfig.GetPrivateEndpoint())
+		case "publicEndpoint":
+			return t.evaluateIP(privateClusterConfig.GetPublicEndpoint())
+		default:
+			return false, fmt.Errorf("unknown privateClusterConfig key %v", t.AttributeKey)
+		}
+	case "releaseChannel":
+		const channelKey = "channel"
+		if channelKey == t.AttributeKey {
+			return t.evaluate(g.cluster.GetReleaseChannel().GetChannel().String())
+		}
+		return false, fmt.Errorf("unknown releaseChannel key %v", t.AttributeKey)
+	case "resourceLabels":
+		return t.evaluateLabels(g.cluster.GetResourceLabels())
+	case "selfLink":
+		return t.evaluate(g.cluster.GetSelfLink())
+	case "servicesIpv4Cidr":
+		return t.evaluateCIDR(g.cluster.GetServicesIpv4Cidr())
+	case "status":
+		return t.evaluate(g.cluster.GetStatus().String())
+	case "statusMessage":
+		return t.evaluate(g.cluster.GetStatusMessage())
+	case "subnetwork":
+		return t.evaluate(g.cluster.GetSubnetwork())
+	case "workloadIdentityConfig":
+		const workloadPoolKey = "workloadPool"
+		if workloadPoolKey == t.AttributeKey {
+			return t.evaluate(g.cluster.GetWorkloadIdentityConfig().GetWorkloadPool())
+		}
+		return false, fmt.Errorf("unknown workloadIdentityConfig key %v", t.AttributeKey)
+	case "zone":
+		return t.evaluate(g.cluster.GetZone())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterClusters filters the given GKE clusters according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/container/clusters/list
+//  2. currentMasterVersion, currentNodeVersion, initialClusterVersion and nodePools.version are compared as versions
+//     e.g. currentMasterVersion<1.28 is true for 1.27.3-gke.100
+//  3. clusterIpv4Cidr, servicesIpv4Cidr and privateClusterConfig.masterIpv4CidrBlock are compared as CIDR ranges
+//     e.g. clusterIpv4Cidr:10.0.0.0/8 is true for 10.4.0.0/14
+func FilterClusters(clusters []*containerpb.Cluster, gcpFilter string) ([]*containerpb.Cluster, error) {
+	return filterResources(clusters, gcpFilter, func(cluster *containerpb.Cluster) gcpCluster {
+		return gcpCluster{cluster: cluster}
+	})
+}
@@ -0,0 +1,231 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+type clustersArray []*containerpb.Cluster
+
+func (c clustersArray) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, cluster := range c {
+		sb.WriteString(cluster.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterClusters(t *testing.T) {
+	clusters := clustersArray{
+		{
+			Name:                 "prod-eu",
+			Location:             "europe-west1",
+			CurrentMasterVersion: "1.27.3-gke.100",
+			CurrentNodeVersion:   "1.27.3-gke.100",
+			CreateTime:           "2023-10-24T09:06:40+00:00",
+			ClusterIpv4Cidr:      "10.4.0.0/14",
+			ServicesIpv4Cidr:     "10.8.0.0/20",
+			Status:               containerpb.Cluster_RUNNING,
+			ResourceLabels: map[string]string{
+				"env": "prod",
+			},
+			ReleaseChannel: &containerpb.ReleaseChannel{
+				Channel: containerpb.ReleaseChannel_STABLE,
+			},
+			NodePools: []*containerpb.NodePool{
+				{
+					Name:    "default-pool",
+					Version: "1.27.3-gke.100",
+					Config: &containerpb.NodeConfig{
+						MachineType: "n2-standard-4",
+						DiskSizeGb:  100,
+					},
+					Autoscaling: &containerpb.NodePoolAutoscaling{
+						Enabled:      true,
+						MaxNodeCount: 10,
+					},
+				},
+				{
+					Name:    "gpu-pool",
+					Version: "1.26.5-gke.1200",
+					Config: &containerpb.NodeConfig{
+						MachineType: "a2-highgpu-1g",
+						Spot:        true,
+					},
+				},
+			},
+		},
+		{
+			Name:                 "dev-autopilot",
+			Location:             "us-central1",
+			CurrentMasterVersion: "1.28.1-gke.201",
+			CreateTime:           "2024-01-05T12:00:00Z",
+			Status:               containerpb.Cluster_RECONCILING,
+			Autopilot: &containerpb.Autopilot{
+				Enabled: true,
+			},
+			ReleaseChannel: &containerpb.ReleaseChannel{
+				Channel: containerpb.ReleaseChannel_RAPID,
+			},
+			ResourceLabels: map[string]string{
+				"env": "dev",
+			},
+		},
+		{
+			Name:                 "legacy",
+			Location:             "europe-west1-b",
+			CurrentMasterVersion: "1.9.7-gke.6",
+			Status:               containerpb.Cluster_ERROR,
+			PrivateClusterConfig: &containerpb.PrivateClusterConfig{
+				EnablePrivateNodes:  true,
+				MasterIpv4CidrBlock: "172.16.32.0/28",
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantClusters clustersArray
+		wantErr      bool
+	}{
+		{
+			name: "Version comparison",
+			args: args{
+				gcpFilter: `currentMasterVersion<1.28`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+				clusters[2],
+			},
+		},
+		{
+			name: "Exact version equality",
+			args: args{
+				gcpFilter: `currentMasterVersion=1.28 OR currentMasterVersion=1.28.1-gke.201 OR (currentMasterVersion>=1.27.3-gke.99 AND status=RUNNING)`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+				clusters[1],
+			},
+		},
+		{
+			name: "Version not equal to any of a list",
+			args: args{
+				gcpFilter: `currentMasterVersion!=(1.27.3-gke.100 1.28.1-gke.201)`,
+			},
+			wantClusters: clustersArray{
+				clusters[2],
+			},
+		},
+		{
+			name: "Node pools",
+			args: args{
+				gcpFilter: `nodePools.config.machineType:a2-* AND nodePools.version<1.27`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+			},
+		},
+		{
+			name: "Node pools existence and autoscaling",
+			args: args{
+				gcpFilter: `nodePools:* AND nodePools.autoscaling.maxNodeCount>=10`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+			},
+		},
+		{
+			name: "Autopilot, release channel and labels",
+			args: args{
+				gcpFilter: `autopilot.enabled=true AND releaseChannel.channel=RAPID AND resourceLabels.env=dev`,
+			},
+			wantClusters: clustersArray{
+				clusters[1],
+			},
+		},
+		{
+			name: "Location and status",
+			args: args{
+				gcpFilter: `location:europe-west1* AND status:(RUNNING ERROR) AND NOT privateClusterConfig.enablePrivateNodes=true`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+			},
+		},
+		{
+			name: "Create time",
+			args: args{
+				gcpFilter: `createTime>"2024-01-01T00:00:00Z"`,
+			},
+			wantClusters: clustersArray{
+				clusters[1],
+			},
+		},
+		{
+			name: "CIDR ranges",
+			args: args{
+				gcpFilter: `clusterIpv4Cidr:10.0.0.0/8 AND servicesIpv4Cidr:10.8.0.1 AND NOT clusterIpv4Cidr:10.4.0.0/16`,
+			},
+			wantClusters: clustersArray{
+				clusters[0],
+			},
+		},
+		{
+			name: "Master CIDR range",
+			args: args{
+				gcpFilter: `privateClusterConfig.masterIpv4CidrBlock=172.16.0.0/12 OR servicesIpv4Cidr:10.9.*`,
+			},
+			wantClusters: clustersArray{
+				clusters[2],
+			},
+		},
+		{
+			name: "Unknown node pool key",
+			args: args{
+				gcpFilter: `nodePools.config.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClusters, err := FilterClusters(clusters, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterClusters() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotClustersArray := clustersArray(gotClusters)
+			if !reflect.DeepEqual(gotClustersArray, tt.wantClusters) {
+				t.Errorf("FilterClusters(): \"%v\". want: \"%v\"", gotClustersArray, tt.wantClusters)
+			}
+			t.Log(gotClustersArray)
+		})
+	}
+}
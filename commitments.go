@@ -0,0 +1,116 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpCommitment struct {
+	commitment *computepb.Commitment
+}
+
+func (g gcpCommitment) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "autoRenew":
+		return t.evaluate(strconv.FormatBool(g.commitment.GetAutoRenew()))
+	case "category":
+		return t.evaluate(g.commitment.GetCategory())
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.commitment.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.commitment.GetDescription())
+	case "endTimestamp":
+		// e.g. endTimestamp<"2025-01-01T00:00:00Z"
+		return t.evaluateTimestampString(g.commitment.GetEndTimestamp())
+	case "existingReservations":
+		return t.evaluateRepeated(g.commitment.GetExistingReservations())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.commitment.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.commitment.GetKind())
+	case "licenseResource":
+		licenseResource := g.commitment.GetLicenseResource()
+		// Existence check e.g. licenseResource:*
+		if t.AttributeKey == "" && t.existence() {
+			return licenseResource != nil, nil
+		}
+		switch t.AttributeKey {
+		case "amount":
+			return t.evaluate(strconv.FormatInt(licenseResource.GetAmount(), 10))
+		case "coresPerLicense":
+			return t.evaluate(licenseResource.GetCoresPerLicense())
+		case "license":
+			return t.evaluate(licenseResource.GetLicense())
+		default:
+			return false, fmt.Errorf("unknown licenseResource key %v", t.AttributeKey)
+		}
+	case "mergeSourceCommitments":
+		return t.evaluateRepeated(g.commitment.GetMergeSourceCommitments())
+	case "name":
+		return t.evaluate(g.commitment.GetName())
+	case "plan":
+		return t.evaluate(g.commitment.GetPlan())
+	case "region":
+		return t.evaluate(g.commitment.GetRegion())
+	case "reservations":
+		// The reservations are filtered by the keys of FilterReservations e.g. reservations.specificReservation.inUseCount=0
+		return filterRepeated(t, g.commitment.GetReservations(), func(t term, reservation *computepb.Reservation) (bool, error) {
+			return gcpReservation{reservation: reservation}.filterTerm(t)
+		})
+	case "resources":
+		// e.g. resources.type=VCPU AND resources.amount>=96
+		return filterRepeated(t, g.commitment.GetResources(), func(t term, resource *computepb.ResourceCommitment) (bool, error) {
+			switch t.Key {
+			case "acceleratorType":
+				return t.evaluate(resource.GetAcceleratorType())
+			case "amount":
+				return t.evaluate(strconv.FormatInt(resource.GetAmount(), 10))
+			case "type":
+				return t.evaluate(resource.GetType())
+			default:
+				return false, fmt.Errorf("unknown resources key %v", t.Key)
+			}
+		})
+	case "selfLink":
+		return t.evaluate(g.commitment.GetSelfLink())
+	case "splitSourceCommitment":
+		return t.evaluate(g.commitment.GetSplitSourceCommitment())
+	case "startTimestamp":
+		return t.evaluateTimestampString(g.commitment.GetStartTimestamp())
+	case "status":
+		return t.evaluate(g.commitment.GetStatus())
+	case "statusMessage":
+		return t.evaluate(g.commitment.GetStatusMessage())
+	case "type":
+		return t.evaluate(g.commitment.GetType())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterCommitments filters the given commitments according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/regionCommitments/list
+func FilterCommitments(commitments []*computepb.Commitment, gcpFilter string) ([]*computepb.Commitment, error) {
+	return filterResources(commitments, gcpFilter, func(commitment *computepb.Commitment) gcpCommitment {
+		return gcpCommitment{commitment: commitment}
+	})
+}
@@ -0,0 +1,155 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type commitmentsArray []*computepb.Commitment
+
+func (c commitmentsArray) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, commitment := range c {
+		sb.WriteString(commitment.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterCommitments(t *testing.T) {
+	commitments := commitmentsArray{
+		{
+			Name:           toStringPtr("cud-n2-3y"),
+			Region:         toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status:         toStringPtr("ACTIVE"),
+			Plan:           toStringPtr("THIRTY_SIX_MONTH"),
+			Type:           toStringPtr("GENERAL_PURPOSE_N2"),
+			StartTimestamp: toStringPtr("2022-01-01T00:00:00.000-08:00"),
+			EndTimestamp:   toStringPtr("2025-01-01T00:00:00.000-08:00"),
+			AutoRenew:      toBoolPtr(false),
+			Resources: []*computepb.ResourceCommitment{
+				{
+					Type:   toStringPtr("VCPU"),
+					Amount: toInt64Ptr(96),
+				},
+				{
+					Type:   toStringPtr("MEMORY"),
+					Amount: toInt64Ptr(393216),
+				},
+			},
+		},
+		{
+			Name:           toStringPtr("cud-gpus-1y"),
+			Region:         toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-central1"),
+			Status:         toStringPtr("ACTIVE"),
+			Plan:           toStringPtr("TWELVE_MONTH"),
+			Type:           toStringPtr("ACCELERATOR_OPTIMIZED"),
+			StartTimestamp: toStringPtr("2024-03-01T00:00:00.000-08:00"),
+			EndTimestamp:   toStringPtr("2025-03-01T00:00:00.000-08:00"),
+			AutoRenew:      toBoolPtr(true),
+			Resources: []*computepb.ResourceCommitment{
+				{
+					Type:            toStringPtr("ACCELERATOR"),
+					AcceleratorType: toStringPtr("nvidia-tesla-a100"),
+					Amount:          toInt64Ptr(2),
+				},
+			},
+			Reservations: []*computepb.Reservation{
+				{
+					Name: toStringPtr("a2-training"),
+					SpecificReservation: &computepb.AllocationSpecificSKUReservation{
+						Count:      toInt64Ptr(2),
+						InUseCount: toInt64Ptr(0),
+					},
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantCommitments commitmentsArray
+		wantErr         bool
+	}{
+		{
+			name: "Expiring commitments",
+			args: args{
+				gcpFilter: `status=ACTIVE AND endTimestamp<"2025-02-01T00:00:00Z" AND autoRenew=false`,
+			},
+			wantCommitments: commitmentsArray{
+				commitments[0],
+			},
+		},
+		{
+			name: "Plan and resources",
+			args: args{
+				gcpFilter: `plan=THIRTY_SIX_MONTH AND resources.type=VCPU AND resources.amount>=96`,
+			},
+			wantCommitments: commitmentsArray{
+				commitments[0],
+			},
+		},
+		{
+			name: "Unused reservations",
+			args: args{
+				gcpFilter: `reservations.specificReservation.inUseCount=0 AND startTimestamp>="2024-03-01T08:00:00Z"`,
+			},
+			wantCommitments: commitmentsArray{
+				commitments[1],
+			},
+		},
+		{
+			name: "Invalid end timestamp",
+			args: args{
+				gcpFilter: `endTimestamp<next-year`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Key with different case",
+			args: args{
+				gcpFilter: `Plan=THIRTY_SIX_MONTH`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCommitments, err := FilterCommitments(commitments, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterCommitments() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotCommitmentsArray := commitmentsArray(gotCommitments)
+			if !reflect.DeepEqual(gotCommitmentsArray, tt.wantCommitments) {
+				t.Errorf("FilterCommitments(): \"%v\". want: \"%v\"", gotCommitmentsArray, tt.wantCommitments)
+			}
+			t.Log(gotCommitmentsArray)
+		})
+	}
+}
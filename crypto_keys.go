@@ -0,0 +1,112 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+)
+
+type gcpCryptoKey struct {
+	cryptoKey *kmspb.CryptoKey
+}
+
+func (g gcpCryptoKey) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "createTime":
+		return t.evaluateProtoTimestamp(g.cryptoKey.GetCreateTime())
+	case "cryptoKeyBackend":
+		return t.evaluate(g.cryptoKey.GetCryptoKeyBackend())
+	case "destroyScheduledDuration":
+		return t.evaluateProtoDuration(g.cryptoKey.GetDestroyScheduledDuration())
+	case "importOnly":
+		return t.evaluate(strconv.FormatBool(g.cryptoKey.GetImportOnly()))
+	case "labels":
+		return t.evaluateLabels(g.cryptoKey.GetLabels())
+	case "name":
+		return t.evaluate(g.cryptoKey.GetName())
+	case "nextRotationTime":
+		return t.evaluateProtoTimestamp(g.cryptoKey.GetNextRotationTime())
+	case "primary":
+		// Existence check e.g. primary:*
+		if t.AttributeKey == "" && t.existence() {
+			return g.cryptoKey.GetPrimary() != nil, nil
+		}
+		return filterCryptoKeyVersion(t.descend(), g.cryptoKey.GetPrimary())
+	case "protectionLevel":
+		// Shorthand of versionTemplate.protectionLevel
+		return t.evaluate(g.cryptoKey.GetVersionTemplate().GetProtectionLevel().String())
+	case "purpose":
+		return t.evaluate(g.cryptoKey.GetPurpose().String())
+	case "rotationPeriod":
+		return t.evaluateProtoDuration(g.cryptoKey.GetRotationPeriod())
+	case "versionTemplate":
+		versionTemplate := g.cryptoKey.GetVersionTemplate()
+		switch t.AttributeKey {
+		case "algorithm":
+			return t.evaluate(versionTemplate.GetAlgorithm().String())
+		case "protectionLevel":
+			return t.evaluate(versionTemplate.GetProtectionLevel().String())
+		default:
+			return false, fmt.Errorf("unknown versionTemplate key %v", t.AttributeKey)
+		}
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterCryptoKeyVersion(t term, cryptoKeyVersion *kmspb.CryptoKeyVersion) (bool, error) {
+	switch t.Key {
+	case "algorithm":
+		return t.evaluate(cryptoKeyVersion.GetAlgorithm().String())
+	case "createTime":
+		return t.evaluateProtoTimestamp(cryptoKeyVersion.GetCreateTime())
+	case "destroyEventTime":
+		return t.evaluateProtoTimestamp(cryptoKeyVersion.GetDestroyEventTime())
+	case "destroyTime":
+		return t.evaluateProtoTimestamp(cryptoKeyVersion.GetDestroyTime())
+	case "generateTime":
+		return t.evaluateProtoTimestamp(cryptoKeyVersion.GetGenerateTime())
+	case "importJob":
+		return t.evaluate(cryptoKeyVersion.GetImportJob())
+	case "importTime":
+		return t.evaluateProtoTimestamp(cryptoKeyVersion.GetImportTime())
+	case "name":
+		return t.evaluate(cryptoKeyVersion.GetName())
+	case "protectionLevel":
+		return t.evaluate(cryptoKeyVersion.GetProtectionLevel().String())
+	case "reimportEligible":
+		return t.evaluate(strconv.FormatBool(cryptoKeyVersion.GetReimportEligible()))
+	case "state":
+		return t.evaluate(cryptoKeyVersion.GetState().String())
+	default:
+		return false, fmt.Errorf("unknown primary key %v", t.Key)
+	}
+}
+
+// FilterCryptoKeys filters the given KMS crypto keys according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/kms/keys/list
+//  2. protectionLevel is a shorthand of versionTemplate.protectionLevel
+//  3. Keys without automatic rotation can be found with -rotationPeriod:*
+func FilterCryptoKeys(cryptoKeys []*kmspb.CryptoKey, gcpFilter string) ([]*kmspb.CryptoKey, error) {
+	return filterResources(cryptoKeys, gcpFilter, func(cryptoKey *kmspb.CryptoKey) gcpCryptoKey {
+		return gcpCryptoKey{cryptoKey: cryptoKey}
+	})
+}
@@ -0,0 +1,156 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type cryptoKeysArray []*kmspb.CryptoKey
+
+func (c cryptoKeysArray) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, cryptoKey := range c {
+		sb.WriteString(cryptoKey.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterCryptoKeys(t *testing.T) {
+	cryptoKeys := cryptoKeysArray{
+		{
+			Name:    "projects/appgate-dev/locations/europe/keyRings/data/cryptoKeys/bigquery",
+			Purpose: kmspb.CryptoKey_ENCRYPT_DECRYPT,
+			Primary: &kmspb.CryptoKeyVersion{
+				Name:            "projects/appgate-dev/locations/europe/keyRings/data/cryptoKeys/bigquery/cryptoKeyVersions/3",
+				State:           kmspb.CryptoKeyVersion_ENABLED,
+				ProtectionLevel: kmspb.ProtectionLevel_HSM,
+				Algorithm:       kmspb.CryptoKeyVersion_GOOGLE_SYMMETRIC_ENCRYPTION,
+				CreateTime:      timestamppb.New(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)),
+			},
+			CreateTime:       timestamppb.New(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)),
+			NextRotationTime: timestamppb.New(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)),
+			RotationSchedule: &kmspb.CryptoKey_RotationPeriod{
+				RotationPeriod: durationpb.New(90 * 24 * time.Hour),
+			},
+			VersionTemplate: &kmspb.CryptoKeyVersionTemplate{
+				ProtectionLevel: kmspb.ProtectionLevel_HSM,
+				Algorithm:       kmspb.CryptoKeyVersion_GOOGLE_SYMMETRIC_ENCRYPTION,
+			},
+			Labels: map[string]string{
+				"team": "data",
+			},
+		},
+		{
+			Name:    "projects/appgate-dev/locations/global/keyRings/signing/cryptoKeys/releases",
+			Purpose: kmspb.CryptoKey_ASYMMETRIC_SIGN,
+			VersionTemplate: &kmspb.CryptoKeyVersionTemplate{
+				ProtectionLevel: kmspb.ProtectionLevel_SOFTWARE,
+				Algorithm:       kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+			},
+			DestroyScheduledDuration: durationpb.New(30 * 24 * time.Hour),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantCryptoKeys cryptoKeysArray
+		wantErr        bool
+	}{
+		{
+			name: "Keys without rotation",
+			args: args{
+				gcpFilter: `-rotationPeriod:* AND -nextRotationTime:*`,
+			},
+			wantCryptoKeys: cryptoKeysArray{
+				cryptoKeys[1],
+			},
+		},
+		{
+			name: "Rotation period and next rotation time",
+			args: args{
+				gcpFilter: `rotationPeriod<=P90D AND rotationPeriod>30d AND nextRotationTime<"2024-07-01T00:00:00Z"`,
+			},
+			wantCryptoKeys: cryptoKeysArray{
+				cryptoKeys[0],
+			},
+		},
+		{
+			name: "Purpose and primary version",
+			args: args{
+				gcpFilter: `purpose=ENCRYPT_DECRYPT AND primary.state=ENABLED AND primary.protectionLevel=HSM AND primary.createTime>"2024-01-01T00:00:00Z"`,
+			},
+			wantCryptoKeys: cryptoKeysArray{
+				cryptoKeys[0],
+			},
+		},
+		{
+			name: "Version template and protection level",
+			args: args{
+				gcpFilter: `versionTemplate.algorithm:EC_SIGN_* OR protectionLevel=HSM`,
+			},
+			wantCryptoKeys: cryptoKeysArray{
+				cryptoKeys[0],
+				cryptoKeys[1],
+			},
+		},
+		{
+			name: "Labels, destroy scheduled duration and no primary version",
+			args: args{
+				gcpFilter: `NOT labels.team:* AND destroyScheduledDuration=30d AND NOT primary:*`,
+			},
+			wantCryptoKeys: cryptoKeysArray{
+				cryptoKeys[1],
+			},
+		},
+		{
+			name: "Unknown primary key",
+			args: args{
+				gcpFilter: `primary.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCryptoKeys, err := FilterCryptoKeys(cryptoKeys, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterCryptoKeys() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotCryptoKeysArray := cryptoKeysArray(gotCryptoKeys)
+			if !reflect.DeepEqual(gotCryptoKeysArray, tt.wantCryptoKeys) {
+				t.Errorf("FilterCryptoKeys(): \"%v\". want: \"%v\"", gotCryptoKeysArray, tt.wantCryptoKeys)
+			}
+			t.Log(gotCryptoKeysArray)
+		})
+	}
+}
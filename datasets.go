@@ -0,0 +1,48 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+
+	"google.golang.org/api/bigquery/v2"
+)
+
+type gcpDataset struct {
+	dataset *bigquery.Dataset
+}
+
+func (g gcpDataset) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTime":
+		return t.evaluateEpochMilliseconds(g.dataset.CreationTime, forceSent(g.dataset.CreationTime, "CreationTime", g.dataset.ForceSendFields))
+	case "lastModifiedTime":
+		return t.evaluateEpochMilliseconds(g.dataset.LastModifiedTime, forceSent(g.dataset.LastModifiedTime, "LastModifiedTime", g.dataset.ForceSendFields))
+	}
+	return filterField(t, reflect.ValueOf(g.dataset))
+}
+
+// FilterDatasets filters the given BigQuery datasets according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/bigquery/docs/reference/rest/v2/datasets#Dataset
+//  2. The times in milliseconds since the epoch e.g. creationTime are compared with RFC3339 times
+//     e.g. creationTime<"2024-01-01T00:00:00Z" or milliseconds e.g. creationTime<1704067200000
+func FilterDatasets(datasets []*bigquery.Dataset, gcpFilter string) ([]*bigquery.Dataset, error) {
+	return filterResources(datasets, gcpFilter, func(dataset *bigquery.Dataset) gcpDataset {
+		return gcpDataset{dataset: dataset}
+	})
+}
@@ -0,0 +1,136 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/bigquery/v2"
+)
+
+type datasetsArray []*bigquery.Dataset
+
+func (d datasetsArray) String() string {
+	if len(d) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, dataset := range d {
+		sb.WriteString(dataset.Id + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterDatasets(t *testing.T) {
+	datasets := datasetsArray{
+		{
+			Id:       "appgate-dev:customers",
+			Location: "EU",
+			DatasetReference: &bigquery.DatasetReference{
+				DatasetId: "customers",
+				ProjectId: "appgate-dev",
+			},
+			DefaultTableExpirationMs: 3600000,
+			CreationTime:             1698138400108,
+			Labels: map[string]string{
+				"pii": "true",
+			},
+		},
+		{
+			Id:       "appgate-dev:raw_events",
+			Location: "europe-west1",
+			DatasetReference: &bigquery.DatasetReference{
+				DatasetId: "raw_events",
+				ProjectId: "appgate-dev",
+			},
+			CreationTime:       1709294400000,
+			LastModifiedTime:   1709298000000,
+			MaxTimeTravelHours: 168,
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantDatasets datasetsArray
+		wantErr      bool
+	}{
+		{
+			name: "Location, default table expiration and labels",
+			args: args{
+				gcpFilter: `location:EU defaultTableExpirationMs<86400000 labels.pii:true`,
+			},
+			wantDatasets: datasetsArray{
+				datasets[0],
+			},
+		},
+		{
+			name: "Creation time",
+			args: args{
+				gcpFilter: `creationTime>"2024-01-01T00:00:00Z" AND datasetReference.datasetId:raw_*`,
+			},
+			wantDatasets: datasetsArray{
+				datasets[1],
+			},
+		},
+		{
+			name: "Times in milliseconds",
+			args: args{
+				gcpFilter: `creationTime<1700000000000 OR lastModifiedTime>=1709298000000`,
+			},
+			wantDatasets: datasetsArray{
+				datasets[0],
+				datasets[1],
+			},
+		},
+		{
+			name: "Unset times",
+			args: args{
+				gcpFilter: `NOT lastModifiedTime:* AND NOT maxTimeTravelHours>0`,
+			},
+			wantDatasets: datasetsArray{
+				datasets[0],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDatasets, err := FilterDatasets(datasets, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterDatasets() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotDatasetsArray := datasetsArray(gotDatasets)
+			if !reflect.DeepEqual(gotDatasetsArray, tt.wantDatasets) {
+				t.Errorf("FilterDatasets(): \"%v\". want: \"%v\"", gotDatasetsArray, tt.wantDatasets)
+			}
+			t.Log(gotDatasetsArray)
+		})
+	}
+}
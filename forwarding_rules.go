@@ -34,7 +34,7 @@ func (g gcpForwardingRule) filterTerm(t term) (bool, error) {
 	case "backendService":
 		return t.evaluate(g.forwardingRule.GetBackendService())
 	case "creationTimestamp":
-		return t.evaluate(g.forwardingRule.GetCreationTimestamp())
+		return t.evaluateTimestampString(g.forwardingRule.GetCreationTimestamp())
 	case "description":
 		return t.evaluate(g.forwardingRule.GetDescription())
 	case "fingerprint":
@@ -89,21 +89,7 @@ func (g gcpForwardingRule) filterTerm(t term) (bool, error) {
 // Notes:
 //  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/aggregatedList
 func FilterForwardingRules(forwardingRules []*computepb.ForwardingRule, gcpFilter string) ([]*computepb.ForwardingRule, error) {
-	filteredForwardingRules := make([]*computepb.ForwardingRule, 0, len(forwardingRules))
-	for _, forwardingRule := range forwardingRules {
-		resource := resource[gcpForwardingRule]{
-			gcpResource: gcpForwardingRule{
-				forwardingRule: forwardingRule,
-			},
-			gcpFilter: gcpFilter,
-		}
-		keepForwardingRule, err := resource.filter()
-		if err != nil {
-			return nil, err
-		}
-		if keepForwardingRule {
-			filteredForwardingRules = append(filteredForwardingRules, forwardingRule)
-		}
-	}
-	return filteredForwardingRules, nil
+	return filterResources(forwardingRules, gcpFilter, func(forwardingRule *computepb.ForwardingRule) gcpForwardingRule {
+		return gcpForwardingRule{forwardingRule: forwardingRule}
+	})
 }
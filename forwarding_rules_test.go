@@ -117,6 +117,24 @@ func TestFilterForwardingRules(t *testing.T) {
 				forwardingRules[0],
 			},
 		},
+		{
+			name: "Creation timestamp with offset",
+			args: args{
+				gcpFilter: `creationTimestamp<"2023-12-01T11:00:00Z"`,
+			},
+			wantForwardingRules: forwardingRulesArray{
+				forwardingRules[1],
+			},
+		},
+		{
+			name: "Creation timestamp with dates",
+			args: args{
+				gcpFilter: `creationTimestamp>=2023-12-01 AND creationTimestamp<"2024"`,
+			},
+			wantForwardingRules: forwardingRulesArray{
+				forwardingRules[0],
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,172 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/functions/apiv2/functionspb"
+)
+
+type gcpFunction struct {
+	function *functionspb.Function
+}
+
+func (g gcpFunction) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "buildConfig":
+		return filterBuildConfig(t.descend(), g.function.GetBuildConfig())
+	case "createTime":
+		return t.evaluateProtoTimestamp(g.function.GetCreateTime())
+	case "description":
+		return t.evaluate(g.function.GetDescription())
+	case "environment":
+		return t.evaluate(g.function.GetEnvironment().String())
+	case "eventTrigger":
+		return filterEventTrigger(t.descend(), g.function.GetEventTrigger())
+	case "kmsKeyName":
+		return t.evaluate(g.function.GetKmsKeyName())
+	case "labels":
+		return t.evaluateLabels(g.function.GetLabels())
+	case "name":
+		return t.evaluate(g.function.GetName())
+	case "runtime":
+		// Shorthand of buildConfig.runtime as in the 1st gen functions e.g. runtime:(python37 nodejs10)
+		return t.evaluate(g.function.GetBuildConfig().GetRuntime())
+	case "satisfiesPzs":
+		return t.evaluate(strconv.FormatBool(g.function.GetSatisfiesPzs()))
+	case "serviceConfig":
+		return filterServiceConfig(t.descend(), g.function.GetServiceConfig())
+	case "state":
+		return t.evaluate(g.function.GetState().String())
+	case "stateMessages":
+		return filterRepeated(t, g.function.GetStateMessages(), func(t term, stateMessage *functionspb.StateMessage) (bool, error) {
+			switch t.Key {
+			case "message":
+				return t.evaluate(stateMessage.GetMessage())
+			case "severity":
+				return t.evaluate(stateMessage.GetSeverity().String())
+			case "type":
+				return t.evaluate(stateMessage.GetType())
+			default:
+				return false, fmt.Errorf("unknown stateMessages key %v", t.Key)
+			}
+		})
+	case "updateTime":
+		return t.evaluateProtoTimestamp(g.function.GetUpdateTime())
+	case "url":
+		return t.evaluate(g.function.GetUrl())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterBuildConfig(t term, buildConfig *functionspb.BuildConfig) (bool, error) {
+	switch t.Key {
+	case "build":
+		return t.evaluate(buildConfig.GetBuild())
+	case "dockerRegistry":
+		return t.evaluate(buildConfig.GetDockerRegistry().String())
+	case "dockerRepository":
+		return t.evaluate(buildConfig.GetDockerRepository())
+	case "entryPoint":
+		return t.evaluate(buildConfig.GetEntryPoint())
+	case "environmentVariables":
+		return t.evaluateLabels(buildConfig.GetEnvironmentVariables())
+	case "runtime":
+		return t.evaluate(buildConfig.GetRuntime())
+	case "serviceAccount":
+		return t.evaluate(buildConfig.GetServiceAccount())
+	case "workerPool":
+		return t.evaluate(buildConfig.GetWorkerPool())
+	default:
+		return false, fmt.Errorf("unknown buildConfig key %v", t.Key)
+	}
+}
+
+func filterServiceConfig(t term, serviceConfig *functionspb.ServiceConfig) (bool, error) {
+	switch t.Key {
+	case "allTrafficOnLatestRevision":
+		return t.evaluate(strconv.FormatBool(serviceConfig.GetAllTrafficOnLatestRevision()))
+	case "availableCpu":
+		return t.evaluate(serviceConfig.GetAvailableCpu())
+	case "availableMemory":
+		return t.evaluate(serviceConfig.GetAvailableMemory())
+	case "environmentVariables":
+		return t.evaluateLabels(serviceConfig.GetEnvironmentVariables())
+	case "ingressSettings":
+		return t.evaluate(serviceConfig.GetIngressSettings().String())
+	case "maxInstanceCount":
+		return t.evaluate(strconv.FormatInt(int64(serviceConfig.GetMaxInstanceCount()), 10))
+	case "maxInstanceRequestConcurrency":
+		return t.evaluate(strconv.FormatInt(int64(serviceConfig.GetMaxInstanceRequestConcurrency()), 10))
+	case "minInstanceCount":
+		return t.evaluate(strconv.FormatInt(int64(serviceConfig.GetMinInstanceCount()), 10))
+	case "revision":
+		return t.evaluate(serviceConfig.GetRevision())
+	case "securityLevel":
+		return t.evaluate(serviceConfig.GetSecurityLevel().String())
+	case "service":
+		return t.evaluate(serviceConfig.GetService())
+	case "serviceAccountEmail":
+		return t.evaluate(serviceConfig.GetServiceAccountEmail())
+	case "timeoutSeconds":
+		return t.evaluate(strconv.FormatInt(int64(serviceConfig.GetTimeoutSeconds()), 10))
+	case "uri":
+		return t.evaluate(serviceConfig.GetUri())
+	case "vpcConnector":
+		return t.evaluate(serviceConfig.GetVpcConnector())
+	case "vpcConnectorEgressSettings":
+		return t.evaluate(serviceConfig.GetVpcConnectorEgressSettings().String())
+	default:
+		return false, fmt.Errorf("unknown serviceConfig key %v", t.Key)
+	}
+}
+
+func filterEventTrigger(t term, eventTrigger *functionspb.EventTrigger) (bool, error) {
+	switch t.Key {
+	case "channel":
+		return t.evaluate(eventTrigger.GetChannel())
+	case "eventType":
+		return t.evaluate(eventTrigger.GetEventType())
+	case "pubsubTopic":
+		return t.evaluate(eventTrigger.GetPubsubTopic())
+	case "retryPolicy":
+		return t.evaluate(eventTrigger.GetRetryPolicy().String())
+	case "service":
+		return t.evaluate(eventTrigger.GetService())
+	case "serviceAccountEmail":
+		return t.evaluate(eventTrigger.GetServiceAccountEmail())
+	case "trigger":
+		return t.evaluate(eventTrigger.GetTrigger())
+	case "triggerRegion":
+		return t.evaluate(eventTrigger.GetTriggerRegion())
+	default:
+		return false, fmt.Errorf("unknown eventTrigger key %v", t.Key)
+	}
+}
+
+// FilterFunctions filters the given Cloud Functions according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/functions/docs/reference/rest/v2/projects.locations.functions
+//  2. runtime is a shorthand of buildConfig.runtime
+func FilterFunctions(functions []*functionspb.Function, gcpFilter string) ([]*functionspb.Function, error) {
+	return filterResources(functions, gcpFilter, func(function *functionspb.Function) gcpFunction {
+		return gcpFunction{function: function}
+	})
+}
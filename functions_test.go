@@ -0,0 +1,159 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/functions/apiv2/functionspb"
+)
+
+type functionsArray []*functionspb.Function
+
+func (f functionsArray) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, function := range f {
+		sb.WriteString(function.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterFunctions(t *testing.T) {
+	functions := functionsArray{
+		{
+			Name:        "projects/appgate-dev/locations/europe-west1/functions/thumbnails",
+			State:       functionspb.Function_ACTIVE,
+			Environment: functionspb.Environment_GEN_2,
+			BuildConfig: &functionspb.BuildConfig{
+				Runtime:    "python312",
+				EntryPoint: "handler",
+			},
+			ServiceConfig: &functionspb.ServiceConfig{
+				MaxInstanceCount: 100,
+				AvailableMemory:  "256M",
+				IngressSettings:  functionspb.ServiceConfig_ALLOW_ALL,
+			},
+			EventTrigger: &functionspb.EventTrigger{
+				EventType:   "google.cloud.storage.object.v1.finalized",
+				RetryPolicy: functionspb.EventTrigger_RETRY_POLICY_RETRY,
+			},
+			Labels: map[string]string{
+				"team": "media",
+			},
+		},
+		{
+			Name:        "projects/appgate-dev/locations/us-central1/functions/legacy-webhook",
+			State:       functionspb.Function_ACTIVE,
+			Environment: functionspb.Environment_GEN_1,
+			BuildConfig: &functionspb.BuildConfig{
+				Runtime: "nodejs10",
+			},
+			ServiceConfig: &functionspb.ServiceConfig{
+				MaxInstanceCount: 3000,
+				IngressSettings:  functionspb.ServiceConfig_ALLOW_INTERNAL_ONLY,
+			},
+		},
+		{
+			Name:  "projects/appgate-dev/locations/us-central1/functions/broken",
+			State: functionspb.Function_FAILED,
+			BuildConfig: &functionspb.BuildConfig{
+				Runtime: "python37",
+			},
+			StateMessages: []*functionspb.StateMessage{
+				{
+					Severity: functionspb.StateMessage_ERROR,
+					Type:     "BuildFailed",
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantFunctions functionsArray
+		wantErr       bool
+	}{
+		{
+			name: "Deprecated runtimes",
+			args: args{
+				gcpFilter: `runtime:(python37 nodejs10 go111)`,
+			},
+			wantFunctions: functionsArray{
+				functions[1],
+				functions[2],
+			},
+		},
+		{
+			name: "Build and service config",
+			args: args{
+				gcpFilter: `buildConfig.runtime:python3* AND serviceConfig.maxInstanceCount<=100 AND state=ACTIVE`,
+			},
+			wantFunctions: functionsArray{
+				functions[0],
+			},
+		},
+		{
+			name: "Event trigger, environment and labels",
+			args: args{
+				gcpFilter: `eventTrigger.eventType:google.cloud.storage.* AND environment=GEN_2 AND labels.team=media`,
+			},
+			wantFunctions: functionsArray{
+				functions[0],
+			},
+		},
+		{
+			name: "Ingress settings and state messages",
+			args: args{
+				gcpFilter: `serviceConfig.ingressSettings=ALLOW_INTERNAL_ONLY OR stateMessages.severity=ERROR`,
+			},
+			wantFunctions: functionsArray{
+				functions[1],
+				functions[2],
+			},
+		},
+		{
+			name: "Unknown service config key",
+			args: args{
+				gcpFilter: `serviceConfig.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFunctions, err := FilterFunctions(functions, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterFunctions() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotFunctionsArray := functionsArray(gotFunctions)
+			if !reflect.DeepEqual(gotFunctionsArray, tt.wantFunctions) {
+				t.Errorf("FilterFunctions(): \"%v\". want: \"%v\"", gotFunctionsArray, tt.wantFunctions)
+			}
+			t.Log(gotFunctionsArray)
+		})
+	}
+}
@@ -3,20 +3,49 @@ module github.com/kosmas-valianos/gcloudfilter
 go 1.22.4
 
 require (
+	cloud.google.com/go/asset v1.20.0
 	cloud.google.com/go/compute v1.28.0
+	cloud.google.com/go/container v1.39.0
+	cloud.google.com/go/functions v1.19.0
+	cloud.google.com/go/iam v1.2.0
+	cloud.google.com/go/kms v1.19.0
+	cloud.google.com/go/pubsub v1.42.0
 	cloud.google.com/go/resourcemanager v1.10.0
+	cloud.google.com/go/run v1.5.0
+	cloud.google.com/go/secretmanager v1.14.0
 	github.com/alecthomas/participle/v2 v2.1.1
+	google.golang.org/api v0.193.0
+	google.golang.org/genproto v0.0.0-20240827150818-7e3bb234dfed
+	google.golang.org/genproto/googleapis/api v0.0.0-20240827150818-7e3bb234dfed
 	google.golang.org/protobuf v1.34.2
 )
 
 require (
-	cloud.google.com/go/iam v1.2.0 // indirect
+	cloud.google.com/go/accesscontextmanager v1.9.0 // indirect
+	cloud.google.com/go/auth v0.9.0 // indirect
+	cloud.google.com/go/auth/oauth2adapt v0.2.4 // indirect
+	cloud.google.com/go/compute/metadata v0.5.0 // indirect
 	cloud.google.com/go/longrunning v0.6.0 // indirect
+	cloud.google.com/go/orgpolicy v1.13.0 // indirect
+	cloud.google.com/go/osconfig v1.14.0 // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
+	github.com/google/s2a-go v0.1.8 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/googleapis/enterprise-certificate-proxy v0.3.2 // indirect
+	github.com/googleapis/gax-go/v2 v2.13.0 // indirect
+	go.opencensus.io v0.24.0 // indirect
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.53.0 // indirect
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+	golang.org/x/crypto v0.26.0 // indirect
 	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/oauth2 v0.22.0 // indirect
 	golang.org/x/sys v0.24.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
-	google.golang.org/genproto v0.0.0-20240827150818-7e3bb234dfed // indirect
-	google.golang.org/genproto/googleapis/api v0.0.0-20240827150818-7e3bb234dfed // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240827150818-7e3bb234dfed // indirect
 	google.golang.org/grpc v1.66.0 // indirect
 )
@@ -0,0 +1,131 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+// protocolHealthCheck has the getters which are common to the health checks of every protocol
+// e.g. computepb.HTTPHealthCheck, computepb.TCPHealthCheck
+type protocolHealthCheck interface {
+	GetPort() int32
+	GetPortName() string
+	GetPortSpecification() string
+}
+
+type gcpHealthCheck struct {
+	healthCheck *computepb.HealthCheck
+}
+
+// filterProtocolHealthCheck filters the health check of a protocol e.g. httpHealthCheck.requestPath=/healthz
+func (g gcpHealthCheck) filterProtocolHealthCheck(t term, healthCheck protocolHealthCheck) (bool, error) {
+	switch t.AttributeKey {
+	case "port":
+		return t.evaluate(strconv.FormatInt(int64(healthCheck.GetPort()), 10))
+	case "portName":
+		return t.evaluate(healthCheck.GetPortName())
+	case "portSpecification":
+		return t.evaluate(healthCheck.GetPortSpecification())
+	case "grpcServiceName":
+		if h, ok := healthCheck.(interface{ GetGrpcServiceName() string }); ok {
+			return t.evaluate(h.GetGrpcServiceName())
+		}
+	case "host":
+		if h, ok := healthCheck.(interface{ GetHost() string }); ok {
+			return t.evaluate(h.GetHost())
+		}
+	case "proxyHeader":
+		if h, ok := healthCheck.(interface{ GetProxyHeader() string }); ok {
+			return t.evaluate(h.GetProxyHeader())
+		}
+	case "request":
+		if h, ok := healthCheck.(interface{ GetRequest() string }); ok {
+			return t.evaluate(h.GetRequest())
+		}
+	case "requestPath":
+		if h, ok := healthCheck.(interface{ GetRequestPath() string }); ok {
+			return t.evaluate(h.GetRequestPath())
+		}
+	case "response":
+		if h, ok := healthCheck.(interface{ GetResponse() string }); ok {
+			return t.evaluate(h.GetResponse())
+		}
+	}
+	return false, fmt.Errorf("unknown %v key %v", t.Key, t.AttributeKey)
+}
+
+func (g gcpHealthCheck) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "checkIntervalSec":
+		return t.evaluate(strconv.FormatInt(int64(g.healthCheck.GetCheckIntervalSec()), 10))
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.healthCheck.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.healthCheck.GetDescription())
+	case "grpcHealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetGrpcHealthCheck())
+	case "healthyThreshold":
+		return t.evaluate(strconv.FormatInt(int64(g.healthCheck.GetHealthyThreshold()), 10))
+	case "http2HealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetHttp2HealthCheck())
+	case "httpHealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetHttpHealthCheck())
+	case "httpsHealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetHttpsHealthCheck())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.healthCheck.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.healthCheck.GetKind())
+	case "logConfig":
+		const enableKey = "enable"
+		if enableKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatBool(g.healthCheck.GetLogConfig().GetEnable()))
+		}
+		return false, fmt.Errorf("unknown logConfig key %v", t.AttributeKey)
+	case "name":
+		return t.evaluate(g.healthCheck.GetName())
+	case "region":
+		return t.evaluate(g.healthCheck.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.healthCheck.GetSelfLink())
+	case "sslHealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetSslHealthCheck())
+	case "tcpHealthCheck":
+		return g.filterProtocolHealthCheck(t, g.healthCheck.GetTcpHealthCheck())
+	case "timeoutSec":
+		return t.evaluate(strconv.FormatInt(int64(g.healthCheck.GetTimeoutSec()), 10))
+	case "type":
+		return t.evaluate(g.healthCheck.GetType())
+	case "unhealthyThreshold":
+		return t.evaluate(strconv.FormatInt(int64(g.healthCheck.GetUnhealthyThreshold()), 10))
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterHealthChecks filters the given health checks according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks/aggregatedList
+func FilterHealthChecks(healthChecks []*computepb.HealthCheck, gcpFilter string) ([]*computepb.HealthCheck, error) {
+	return filterResources(healthChecks, gcpFilter, func(healthCheck *computepb.HealthCheck) gcpHealthCheck {
+		return gcpHealthCheck{healthCheck: healthCheck}
+	})
+}
@@ -0,0 +1,128 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type healthChecksArray []*computepb.HealthCheck
+
+func (h healthChecksArray) String() string {
+	if len(h) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, healthCheck := range h {
+		sb.WriteString(healthCheck.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterHealthChecks(t *testing.T) {
+	healthChecks := healthChecksArray{
+		{
+			CheckIntervalSec:  toInt32Ptr(5),
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			HealthyThreshold:  toInt32Ptr(2),
+			HttpHealthCheck: &computepb.HTTPHealthCheck{
+				Port:              toInt32Ptr(80),
+				PortSpecification: toStringPtr("USE_FIXED_PORT"),
+				RequestPath:       toStringPtr("/healthz"),
+			},
+			Id:                 toUint64Ptr(2123462547295123412),
+			Kind:               toStringPtr("compute#healthCheck"),
+			Name:               toStringPtr("http-basic-check"),
+			TimeoutSec:         toInt32Ptr(5),
+			Type:               toStringPtr("HTTP"),
+			UnhealthyThreshold: toInt32Ptr(2),
+		},
+		{
+			CheckIntervalSec:  toInt32Ptr(30),
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			HealthyThreshold:  toInt32Ptr(1),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#healthCheck"),
+			LogConfig: &computepb.HealthCheckLogConfig{
+				Enable: toBoolPtr(true),
+			},
+			Name:   toStringPtr("tcp-check"),
+			Region: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			TcpHealthCheck: &computepb.TCPHealthCheck{
+				Port:        toInt32Ptr(8081),
+				ProxyHeader: toStringPtr("NONE"),
+			},
+			TimeoutSec:         toInt32Ptr(10),
+			Type:               toStringPtr("TCP"),
+			UnhealthyThreshold: toInt32Ptr(5),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantHealthChecks healthChecksArray
+		wantErr          bool
+	}{
+		{
+			name: "HTTP health checks",
+			args: args{
+				gcpFilter: `type=HTTP httpHealthCheck.requestPath=/healthz httpHealthCheck.port=80`,
+			},
+			wantHealthChecks: healthChecksArray{
+				healthChecks[0],
+			},
+		},
+		{
+			name: "Intervals, thresholds, logging",
+			args: args{
+				gcpFilter: `checkIntervalSec>=30 AND unhealthyThreshold>2 logConfig.enable=true tcpHealthCheck.port:8081`,
+			},
+			wantHealthChecks: healthChecksArray{
+				healthChecks[1],
+			},
+		},
+		{
+			name: "Key of another protocol",
+			args: args{
+				gcpFilter: `tcpHealthCheck.requestPath:*`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHealthChecks, err := FilterHealthChecks(healthChecks, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterHealthChecks() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotHealthChecksArray := healthChecksArray(gotHealthChecks)
+			if !reflect.DeepEqual(gotHealthChecksArray, tt.wantHealthChecks) {
+				t.Errorf("FilterHealthChecks(): \"%v\". want: \"%v\"", gotHealthChecksArray, tt.wantHealthChecks)
+			}
+			t.Log(gotHealthChecksArray)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+)
+
+// Hierarchy is the resource hierarchy built from cached folders and organizations.
+// It enables ancestors queries on projects without any extra API calls
+type Hierarchy struct {
+	// Resource name e.g. folders/123 => parent resource name e.g. organizations/456
+	parents map[string]string
+	// Resource name => display name
+	displayNames map[string]string
+}
+
+// NewHierarchy builds the resource hierarchy from the given folders and organizations
+// Notes:
+//  1. Folders are typically retrieved with SearchFolders() and organizations with SearchOrganizations()
+//  2. Folders that are not given terminate the ancestry chain of their children
+func NewHierarchy(folders []*resourcemanagerpb.Folder, organizations []*resourcemanagerpb.Organization) *Hierarchy {
+	h := &Hierarchy{
+		parents:      make(map[string]string, len(folders)),
+		displayNames: make(map[string]string, len(folders)+len(organizations)),
+	}
+	for _, folder := range folders {
+		h.parents[folder.GetName()] = folder.GetParent()
+		h.displayNames[folder.GetName()] = folder.GetDisplayName()
+	}
+	for _, organization := range organizations {
+		h.displayNames[organization.GetName()] = organization.GetDisplayName()
+	}
+	return h
+}
+
+// ancestors returns the ancestors starting from the given direct parent up to the root
+// e.g. [folders/123 folders/456 organizations/789]
+func (h *Hierarchy) ancestors(parent string) ([]string, error) {
+	var ancestors []string
+	visited := make(map[string]bool)
+	for ancestor := parent; ancestor != ""; ancestor = h.parents[ancestor] {
+		if visited[ancestor] {
+			return nil, fmt.Errorf("cycle in hierarchy at %v", ancestor)
+		}
+		visited[ancestor] = true
+		ancestors = append(ancestors, ancestor)
+	}
+	return ancestors, nil
+}
+
+func (h *Hierarchy) displayName(ancestor string) string {
+	return h.displayNames[ancestor]
+}
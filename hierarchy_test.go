@@ -0,0 +1,157 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+)
+
+func TestFilterProjectsWithHierarchy(t *testing.T) {
+	organizations := []*resourcemanagerpb.Organization{
+		{
+			Name:        "organizations/448593862441",
+			DisplayName: "appgate.com",
+		},
+	}
+	folders := []*resourcemanagerpb.Folder{
+		{
+			Name:        "folders/123",
+			Parent:      "organizations/448593862441",
+			DisplayName: "Engineering",
+		},
+		{
+			Name:        "folders/456",
+			Parent:      "folders/123",
+			DisplayName: "Platform",
+		},
+		{
+			Name:        "folders/789",
+			Parent:      "organizations/448593862441",
+			DisplayName: "Sales",
+		},
+	}
+	projects := projectsArray{
+		{
+			Name:        "projects/82699087620",
+			Parent:      "organizations/448593862441",
+			ProjectId:   "appgate-dev",
+			DisplayName: "Appgate Dev",
+		},
+		{
+			Name:        "projects/76499083636",
+			Parent:      "folders/456",
+			ProjectId:   "devops-test",
+			DisplayName: "Devops Test",
+		},
+		{
+			Name:        "projects/82699087621",
+			Parent:      "folders/789",
+			ProjectId:   "sales-crm",
+			DisplayName: "Sales CRM",
+		},
+	}
+	hierarchy := NewHierarchy(folders, organizations)
+
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantProjects projectsArray
+		wantErr      bool
+	}{
+		{
+			name: "Anywhere under a folder",
+			args: args{
+				gcpFilter: `ancestors:folders/123`,
+			},
+			wantProjects: projectsArray{
+				projects[1],
+			},
+		},
+		{
+			name: "Anywhere under an organization",
+			args: args{
+				gcpFilter: `ancestors:organizations/448593862441 AND NOT ancestors.displayName:Sales`,
+			},
+			wantProjects: projectsArray{
+				projects[0],
+				projects[1],
+			},
+		},
+		{
+			name: "Ancestors' ids and types",
+			args: args{
+				gcpFilter: `ancestors.id:(456 789) ancestors.type:folders`,
+			},
+			wantProjects: projectsArray{
+				projects[1],
+				projects[2],
+			},
+		},
+		{
+			name: "Depth",
+			args: args{
+				gcpFilter: `ancestors.depth>=2 AND ancestors.displayName:engi*`,
+			},
+			wantProjects: projectsArray{
+				projects[1],
+			},
+		},
+		{
+			name: "Negative operator",
+			args: args{
+				gcpFilter: `ancestors!=folders/789`,
+			},
+			wantProjects: projectsArray{
+				projects[0],
+				projects[1],
+			},
+		},
+		{
+			name: "Unknown attribute key",
+			args: args{
+				gcpFilter: `ancestors.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProjects, err := FilterProjects(projects, tt.args.gcpFilter, WithHierarchy(hierarchy))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterProjects() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotProjectsArray := projectsArray(gotProjects)
+			if !reflect.DeepEqual(gotProjectsArray, tt.wantProjects) {
+				t.Errorf("FilterProjects(): \"%v\". want: \"%v\"", gotProjectsArray, tt.wantProjects)
+			}
+			t.Log(gotProjectsArray)
+		})
+	}
+
+	t.Run("Without hierarchy", func(t *testing.T) {
+		if _, err := FilterProjects(projects, `ancestors:folders/123`); err == nil {
+			t.Errorf("FilterProjects() error: \"%v\". wantErr: %v", err, true)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+)
+
+type gcpIAMPolicy struct {
+	policy *iampb.Policy
+}
+
+func (g gcpIAMPolicy) filterTerm(t term) (bool, error) {
+	return filterIAMPolicy(t, g.policy)
+}
+
+func filterIAMPolicy(t term, policy *iampb.Policy) (bool, error) {
+	switch t.Key {
+	case "auditConfigs":
+		// e.g. auditConfigs.service=allServices, auditConfigs.auditLogConfigs.logType=DATA_READ
+		return filterRepeated(t, policy.GetAuditConfigs(), filterAuditConfig)
+	case "bindings":
+		// e.g. bindings.role:roles/owner, bindings.members:user:*@gmail.com, bindings.condition:*
+		return filterRepeated(t, policy.GetBindings(), filterBinding)
+	case "version":
+		return t.evaluate(strconv.FormatInt(int64(policy.GetVersion()), 10))
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterBinding(t term, binding *iampb.Binding) (bool, error) {
+	switch t.Key {
+	case "condition":
+		// Existence check e.g. bindings.condition:*
+		if t.AttributeKey == "" && t.existence() {
+			return binding.GetCondition() != nil, nil
+		}
+		condition := binding.GetCondition()
+		switch t.AttributeKey {
+		case "description":
+			return t.evaluate(condition.GetDescription())
+		case "expression":
+			return t.evaluate(condition.GetExpression())
+		case "location":
+			return t.evaluate(condition.GetLocation())
+		case "title":
+			return t.evaluate(condition.GetTitle())
+		default:
+			return false, fmt.Errorf("unknown condition key %v", t.AttributeKey)
+		}
+	case "members":
+		return t.evaluateRepeated(binding.GetMembers())
+	case "role":
+		return t.evaluate(binding.GetRole())
+	default:
+		return false, fmt.Errorf("unknown bindings key %v", t.Key)
+	}
+}
+
+func filterAuditConfig(t term, auditConfig *iampb.AuditConfig) (bool, error) {
+	switch t.Key {
+	case "auditLogConfigs":
+		return filterRepeated(t, auditConfig.GetAuditLogConfigs(), func(t term, auditLogConfig *iampb.AuditLogConfig) (bool, error) {
+			switch t.Key {
+			case "exemptedMembers":
+				return t.evaluateRepeated(auditLogConfig.GetExemptedMembers())
+			case "logType":
+				return t.evaluate(auditLogConfig.GetLogType().String())
+			default:
+				return false, fmt.Errorf("unknown auditLogConfigs key %v", t.Key)
+			}
+		})
+	case "service":
+		return t.evaluate(auditConfig.GetService())
+	default:
+		return false, fmt.Errorf("unknown auditConfigs key %v", t.Key)
+	}
+}
+
+// FilterIAMPolicies filters the given IAM policies according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/iam/docs/reference/rest/v1/Policy
+//     e.g. bindings.role:roles/owner bindings.members:user:*@gmail.com
+//  2. Each term is evaluated against all the bindings therefore the terms of the example may match
+//     different bindings of the same policy as with gcloud. FilterIAMBindings matches them against the same binding
+func FilterIAMPolicies(policies []*iampb.Policy, gcpFilter string) ([]*iampb.Policy, error) {
+	return filterResources(policies, gcpFilter, func(policy *iampb.Policy) gcpIAMPolicy {
+		return gcpIAMPolicy{policy: policy}
+	})
+}
+
+// IAMBinding is a binding of an IAM policy together with its policy
+type IAMBinding struct {
+	Policy  *iampb.Policy
+	Binding *iampb.Binding
+}
+
+type gcpIAMBinding struct {
+	binding IAMBinding
+}
+
+func (g gcpIAMBinding) filterTerm(t term) (bool, error) {
+	if t.Key != "bindings" {
+		return filterIAMPolicy(t, g.binding.Policy)
+	}
+	// Existence check e.g. bindings:*
+	if t.AttributeKey == "" && t.existence() {
+		return true, nil
+	}
+	return filterBinding(t.descend(), g.binding.Binding)
+}
+
+// FilterIAMBindings filters the bindings of the given IAM policies according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of FilterIAMPolicies
+//  2. Unlike FilterIAMPolicies all the bindings terms are evaluated against the same binding therefore
+//     bindings.role:roles/owner AND bindings.members:user:*@gmail.com matches only the owner bindings with gmail
+//     members. The policies of the bindings are the ones of the matching policies
+func FilterIAMBindings(policies []*iampb.Policy, gcpFilter string) ([]IAMBinding, error) {
+	bindings := make([]IAMBinding, 0, len(policies))
+	for _, policy := range policies {
+		for _, binding := range policy.GetBindings() {
+			bindings = append(bindings, IAMBinding{Policy: policy, Binding: binding})
+		}
+	}
+	return filterResources(bindings, gcpFilter, func(binding IAMBinding) gcpIAMBinding {
+		return gcpIAMBinding{binding: binding}
+	})
+}
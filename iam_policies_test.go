@@ -0,0 +1,305 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+type iamPoliciesArray []*iampb.Policy
+
+func (i iamPoliciesArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, policy := range i {
+		sb.WriteString(string(policy.GetEtag()) + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+type iamBindingsArray []IAMBinding
+
+func (i iamBindingsArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, binding := range i {
+		sb.WriteString(string(binding.Policy.GetEtag()) + "/" + binding.Binding.GetRole() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterIAMPolicies(t *testing.T) {
+	policies := iamPoliciesArray{
+		{
+			Version: 1,
+			Etag:    []byte("BwYQ1"),
+			Bindings: []*iampb.Binding{
+				{
+					Role:    "roles/owner",
+					Members: []string{"user:admin@appgate.com", "user:john.doe@gmail.com"},
+				},
+				{
+					Role:    "roles/viewer",
+					Members: []string{"group:engineering@appgate.com"},
+				},
+			},
+		},
+		{
+			Version: 3,
+			Etag:    []byte("BwYQ2"),
+			Bindings: []*iampb.Binding{
+				{
+					Role:    "roles/owner",
+					Members: []string{"serviceAccount:ci-deployer@appgate-dev.iam.gserviceaccount.com"},
+				},
+				{
+					Role:    "roles/compute.admin",
+					Members: []string{"user:contractor@gmail.com"},
+					Condition: &expr.Expr{
+						Title:      "expires-2024",
+						Expression: `request.time < timestamp("2024-06-01T00:00:00Z")`,
+					},
+				},
+			},
+			AuditConfigs: []*iampb.AuditConfig{
+				{
+					Service: "allServices",
+					AuditLogConfigs: []*iampb.AuditLogConfig{
+						{
+							LogType:         iampb.AuditLogConfig_DATA_READ,
+							ExemptedMembers: []string{"user:admin@appgate.com"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Version: 1,
+			Etag:    []byte("BwYQ3"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantIAMPolicies iamPoliciesArray
+		wantErr         bool
+	}{
+		{
+			name: "Owners and gmail members",
+			args: args{
+				gcpFilter: `bindings.role:roles/owner bindings.members:user:*@gmail.com`,
+			},
+			wantIAMPolicies: iamPoliciesArray{
+				policies[0],
+				policies[1],
+			},
+		},
+		{
+			name: "Exact member",
+			args: args{
+				gcpFilter: `bindings.members=user:admin@appgate.com`,
+			},
+			wantIAMPolicies: iamPoliciesArray{
+				policies[0],
+			},
+		},
+		{
+			name: "Conditions",
+			args: args{
+				gcpFilter: `bindings.condition:* AND bindings.condition.title:expires-* AND version>=3`,
+			},
+			wantIAMPolicies: iamPoliciesArray{
+				policies[1],
+			},
+		},
+		{
+			name: "Audit configs",
+			args: args{
+				gcpFilter: `auditConfigs.auditLogConfigs.logType=DATA_READ AND auditConfigs.service=allServices`,
+			},
+			wantIAMPolicies: iamPoliciesArray{
+				policies[1],
+			},
+		},
+		{
+			name: "Without bindings",
+			args: args{
+				gcpFilter: `NOT bindings:*`,
+			},
+			wantIAMPolicies: iamPoliciesArray{
+				policies[2],
+			},
+		},
+		{
+			name: "Unknown bindings key",
+			args: args{
+				gcpFilter: `bindings.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIAMPolicies, err := FilterIAMPolicies(policies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterIAMPolicies() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotIAMPoliciesArray := iamPoliciesArray(gotIAMPolicies)
+			if !reflect.DeepEqual(gotIAMPoliciesArray, tt.wantIAMPolicies) {
+				t.Errorf("FilterIAMPolicies(): \"%v\". want: \"%v\"", gotIAMPoliciesArray, tt.wantIAMPolicies)
+			}
+			t.Log(gotIAMPoliciesArray)
+		})
+	}
+
+	bindingsTests := []struct {
+		name            string
+		args            args
+		wantIAMBindings iamBindingsArray
+		wantErr         bool
+	}{
+		{
+			name: "Owner bindings with gmail members",
+			args: args{
+				gcpFilter: `bindings.role:roles/owner AND bindings.members:user:*@gmail.com`,
+			},
+			wantIAMBindings: iamBindingsArray{
+				{Policy: policies[0], Binding: policies[0].Bindings[0]},
+			},
+		},
+		{
+			name: "Owner bindings without gmail members",
+			args: args{
+				gcpFilter: `bindings.role:roles/owner AND NOT bindings.members:user:*@gmail.com`,
+			},
+			wantIAMBindings: iamBindingsArray{
+				{Policy: policies[1], Binding: policies[1].Bindings[0]},
+			},
+		},
+		{
+			name: "Conditional bindings of audited policies",
+			args: args{
+				gcpFilter: `bindings.condition.title:expires-* AND auditConfigs:* AND version>=3`,
+			},
+			wantIAMBindings: iamBindingsArray{
+				{Policy: policies[1], Binding: policies[1].Bindings[1]},
+			},
+		},
+		{
+			name: "Unknown bindings key",
+			args: args{
+				gcpFilter: `bindings.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range bindingsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIAMBindings, err := FilterIAMBindings(policies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterIAMBindings() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotIAMBindingsArray := iamBindingsArray(gotIAMBindings)
+			if !reflect.DeepEqual(gotIAMBindingsArray, tt.wantIAMBindings) {
+				t.Errorf("FilterIAMBindings(): \"%v\". want: \"%v\"", gotIAMBindingsArray, tt.wantIAMBindings)
+			}
+			t.Log(gotIAMBindingsArray)
+		})
+	}
+
+	projects := projectsArray{
+		{
+			Name:      "projects/82699087620",
+			ProjectId: "appgate-dev",
+		},
+		{
+			Name:      "projects/76499083636",
+			ProjectId: "devops-test",
+		},
+		{
+			Name:      "projects/82699087621",
+			ProjectId: "sales-crm",
+		},
+	}
+	iamPolicies := map[string]*iampb.Policy{
+		"projects/82699087620": policies[0],
+		"devops-test":          policies[1],
+	}
+	projectsTests := []struct {
+		name         string
+		args         args
+		wantProjects projectsArray
+		wantErr      bool
+	}{
+		{
+			name: "Projects with owners and gmail members",
+			args: args{
+				gcpFilter: `iamPolicy.bindings.role:roles/owner AND iamPolicy.bindings.members:user:*@gmail.com`,
+			},
+			wantProjects: projectsArray{
+				projects[0],
+				projects[1],
+			},
+		},
+		{
+			name: "Projects without a policy",
+			args: args{
+				gcpFilter: `NOT iamPolicy:* OR iamPolicy.auditConfigs:*`,
+			},
+			wantProjects: projectsArray{
+				projects[1],
+				projects[2],
+			},
+		},
+	}
+	for _, tt := range projectsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProjects, err := FilterProjects(projects, tt.args.gcpFilter, WithIAMPolicies(iamPolicies))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterProjects() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotProjectsArray := projectsArray(gotProjects)
+			if !reflect.DeepEqual(gotProjectsArray, tt.wantProjects) {
+				t.Errorf("FilterProjects(): \"%v\". want: \"%v\"", gotProjectsArray, tt.wantProjects)
+			}
+			t.Log(gotProjectsArray)
+		})
+	}
+
+	t.Run("Without IAM policies", func(t *testing.T) {
+		if _, err := FilterProjects(projects, `iamPolicy.bindings.role:roles/owner`); err == nil {
+			t.Errorf("FilterProjects() error: \"%v\". wantErr: %v", err, true)
+		}
+	})
+}
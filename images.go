@@ -0,0 +1,105 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpImage struct {
+	image *computepb.Image
+}
+
+func (g gcpImage) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "architecture":
+		return t.evaluate(g.image.GetArchitecture())
+	case "archiveSizeBytes":
+		return t.evaluate(strconv.FormatInt(g.image.GetArchiveSizeBytes(), 10))
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.image.GetCreationTimestamp())
+	case "deprecated":
+		return filterDeprecationStatus(t, g.image.GetDeprecated())
+	case "description":
+		return t.evaluate(g.image.GetDescription())
+	case "diskSizeGb":
+		return t.evaluate(strconv.FormatInt(g.image.GetDiskSizeGb(), 10))
+	case "family":
+		return t.evaluate(g.image.GetFamily())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.image.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.image.GetKind())
+	case "labelFingerprint":
+		return t.evaluate(g.image.GetLabelFingerprint())
+	case "labels":
+		return t.evaluateLabels(g.image.GetLabels())
+	case "licenses":
+		return t.evaluateRepeated(g.image.GetLicenses())
+	case "name":
+		return t.evaluate(g.image.GetName())
+	case "selfLink":
+		return t.evaluate(g.image.GetSelfLink())
+	case "sourceDisk":
+		return t.evaluate(g.image.GetSourceDisk())
+	case "sourceDiskId":
+		return t.evaluate(g.image.GetSourceDiskId())
+	case "sourceImage":
+		return t.evaluate(g.image.GetSourceImage())
+	case "sourceSnapshot":
+		return t.evaluate(g.image.GetSourceSnapshot())
+	case "sourceType":
+		return t.evaluate(g.image.GetSourceType())
+	case "status":
+		return t.evaluate(g.image.GetStatus())
+	case "storageLocations":
+		return t.evaluateRepeated(g.image.GetStorageLocations())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// filterDeprecationStatus filters the deprecation status of images, zones, regions and machine types
+// e.g. deprecated.state=DEPRECATED, deprecated.obsolete<2024-01-01T00:00:00Z
+func filterDeprecationStatus(t term, deprecated *computepb.DeprecationStatus) (bool, error) {
+	switch t.AttributeKey {
+	case "state":
+		return t.evaluate(deprecated.GetState())
+	case "replacement":
+		return t.evaluate(deprecated.GetReplacement())
+	case "deprecated":
+		return t.evaluateTimestampString(deprecated.GetDeprecated())
+	case "obsolete":
+		return t.evaluateTimestampString(deprecated.GetObsolete())
+	case "deleted":
+		return t.evaluateTimestampString(deprecated.GetDeleted())
+	default:
+		return false, fmt.Errorf("unknown deprecated key %v", t.AttributeKey)
+	}
+}
+
+// FilterImages filters the given images according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/images/list
+func FilterImages(images []*computepb.Image, gcpFilter string) ([]*computepb.Image, error) {
+	return filterResources(images, gcpFilter, func(image *computepb.Image) gcpImage {
+		return gcpImage{image: image}
+	})
+}
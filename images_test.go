@@ -0,0 +1,157 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type imagesArray []*computepb.Image
+
+func (i imagesArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, image := range i {
+		sb.WriteString(image.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func toInt64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestFilterImages(t *testing.T) {
+	images := imagesArray{
+		{
+			ArchiveSizeBytes:  toInt64Ptr(1536873152),
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Deprecated: &computepb.DeprecationStatus{
+				State:       toStringPtr("DEPRECATED"),
+				Replacement: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/images/appgate-sdp-6-2-2"),
+				Obsolete:    toStringPtr("2024-01-01T00:00:00.000-08:00"),
+			},
+			DiskSizeGb:       toInt64Ptr(10),
+			Family:           toStringPtr("appgate-sdp-6-2"),
+			Id:               toUint64Ptr(2123462547295123412),
+			Kind:             toStringPtr("compute#image"),
+			Name:             toStringPtr("appgate-sdp-6-2-1"),
+			SourceDisk:       toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/disks/builder"),
+			Status:           toStringPtr("READY"),
+			StorageLocations: []string{"eu"},
+			Labels: map[string]string{
+				"retention": "30d",
+			},
+		},
+		{
+			ArchiveSizeBytes:  toInt64Ptr(2147483648),
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			DiskSizeGb:        toInt64Ptr(20),
+			Family:            toStringPtr("appgate-sdp-6-2"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#image"),
+			Name:              toStringPtr("appgate-sdp-6-2-2"),
+			SourceDisk:        toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/disks/builder"),
+			Status:            toStringPtr("READY"),
+			StorageLocations:  []string{"eu", "us"},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantImages imagesArray
+		wantErr    bool
+	}{
+		{
+			name: "Deprecated images",
+			args: args{
+				gcpFilter: `family=appgate-sdp-6-2 deprecated.state=DEPRECATED deprecated.obsolete<"2024-01-02T00:00:00Z"`,
+			},
+			wantImages: imagesArray{
+				images[0],
+			},
+		},
+		{
+			name: "Sizes and storage locations",
+			args: args{
+				gcpFilter: `diskSizeGb>10 AND archiveSizeBytes>=2147483648 storageLocations:us -deprecated.state:*`,
+			},
+			wantImages: imagesArray{
+				images[1],
+			},
+		},
+		{
+			name: "Creation timestamp with offset",
+			args: args{
+				gcpFilter: `creationTimestamp>"2023-12-01T12:00:00+01:00" OR labels.retention:*`,
+			},
+			wantImages: imagesArray{
+				images[0],
+				images[1],
+			},
+		},
+		{
+			name: "Unset timestamps with negative operators",
+			args: args{
+				gcpFilter: `deprecated.obsolete!="2024-01-01T08:00:00Z"`,
+			},
+			wantImages: imagesArray{
+				images[1],
+			},
+		},
+		{
+			name: "Timestamps with milliseconds and patterns",
+			args: args{
+				gcpFilter: `creationTimestamp>"2023-12-01T11:52:49Z" AND creationTimestamp:2023-12-01*`,
+			},
+			wantImages: imagesArray{
+				images[1],
+			},
+		},
+		{
+			name: "Unknown deprecated key",
+			args: args{
+				gcpFilter: `deprecated.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotImages, err := FilterImages(images, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterImages() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotImagesArray := imagesArray(gotImages)
+			if !reflect.DeepEqual(gotImagesArray, tt.wantImages) {
+				t.Errorf("FilterImages(): \"%v\". want: \"%v\"", gotImagesArray, tt.wantImages)
+			}
+			t.Log(gotImagesArray)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpInstanceGroupManager struct {
+	instanceGroupManager *computepb.InstanceGroupManager
+}
+
+func (g gcpInstanceGroupManager) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "autoHealingPolicies":
+		return filterRepeated(t, g.instanceGroupManager.GetAutoHealingPolicies(), func(t term, autoHealingPolicy *computepb.InstanceGroupManagerAutoHealingPolicy) (bool, error) {
+			switch t.Key {
+			case "healthCheck":
+				return t.evaluate(autoHealingPolicy.GetHealthCheck())
+			case "initialDelaySec":
+				return t.evaluate(strconv.FormatInt(int64(autoHealingPolicy.GetInitialDelaySec()), 10))
+			default:
+				return false, fmt.Errorf("unknown autoHealingPolicies key %v", t.Key)
+			}
+		})
+	case "baseInstanceName":
+		return t.evaluate(g.instanceGroupManager.GetBaseInstanceName())
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.instanceGroupManager.GetCreationTimestamp())
+	case "currentActions":
+		// e.g. currentActions.creating>0
+		currentActions := g.instanceGroupManager.GetCurrentActions()
+		var currentActionsValue int32
+		switch t.AttributeKey {
+		case "abandoning":
+			currentActionsValue = currentActions.GetAbandoning()
+		case "creating":
+			currentActionsValue = currentActions.GetCreating()
+		case "creatingWithoutRetries":
+			currentActionsValue = currentActions.GetCreatingWithoutRetries()
+		case "deleting":
+			currentActionsValue = currentActions.GetDeleting()
+		case "none":
+			currentActionsValue = currentActions.GetNone()
+		case "recreating":
+			currentActionsValue = currentActions.GetRecreating()
+		case "refreshing":
+			currentActionsValue = currentActions.GetRefreshing()
+		case "restarting":
+			currentActionsValue = currentActions.GetRestarting()
+		case "verifying":
+			currentActionsValue = currentActions.GetVerifying()
+		default:
+			return false, fmt.Errorf("unknown currentActions key %v", t.AttributeKey)
+		}
+		return t.evaluate(strconv.FormatInt(int64(currentActionsValue), 10))
+	case "description":
+		return t.evaluate(g.instanceGroupManager.GetDescription())
+	case "fingerprint":
+		return t.evaluate(g.instanceGroupManager.GetFingerprint())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.instanceGroupManager.GetId(), 10))
+	case "instanceGroup":
+		return t.evaluate(g.instanceGroupManager.GetInstanceGroup())
+	case "instanceTemplate":
+		return t.evaluate(g.instanceGroupManager.GetInstanceTemplate())
+	case "kind":
+		return t.evaluate(g.instanceGroupManager.GetKind())
+	case "name":
+		return t.evaluate(g.instanceGroupManager.GetName())
+	case "namedPorts":
+		return filterRepeated(t, g.instanceGroupManager.GetNamedPorts(), filterNamedPort)
+	case "region":
+		return t.evaluate(g.instanceGroupManager.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.instanceGroupManager.GetSelfLink())
+	case "status":
+		// e.g. status.isStable=false, status.versionTarget.isReached=true
+		status := g.instanceGroupManager.GetStatus()
+		switch t.AttributeKey {
+		case "autoscaler":
+			return t.evaluate(status.GetAutoscaler())
+		case "isStable":
+			return t.evaluate(strconv.FormatBool(status.GetIsStable()))
+		case "stateful.hasStatefulConfig":
+			return t.evaluate(strconv.FormatBool(status.GetStateful().GetHasStatefulConfig()))
+		case "versionTarget.isReached":
+			return t.evaluate(strconv.FormatBool(status.GetVersionTarget().GetIsReached()))
+		default:
+			return false, fmt.Errorf("unknown status key %v", t.AttributeKey)
+		}
+	case "targetPools":
+		return t.evaluateRepeated(g.instanceGroupManager.GetTargetPools())
+	case "targetSize":
+		return t.evaluate(strconv.FormatInt(int64(g.instanceGroupManager.GetTargetSize()), 10))
+	case "updatePolicy":
+		updatePolicy := g.instanceGroupManager.GetUpdatePolicy()
+		switch t.AttributeKey {
+		case "instanceRedistributionType":
+			return t.evaluate(updatePolicy.GetInstanceRedistributionType())
+		case "maxSurge.fixed":
+			return t.evaluate(strconv.FormatInt(int64(updatePolicy.GetMaxSurge().GetFixed()), 10))
+		case "maxSurge.percent":
+			return t.evaluate(strconv.FormatInt(int64(updatePolicy.GetMaxSurge().GetPercent()), 10))
+		case "maxUnavailable.fixed":
+			return t.evaluate(strconv.FormatInt(int64(updatePolicy.GetMaxUnavailable().GetFixed()), 10))
+		case "maxUnavailable.percent":
+			return t.evaluate(strconv.FormatInt(int64(updatePolicy.GetMaxUnavailable().GetPercent()), 10))
+		case "minimalAction":
+			return t.evaluate(updatePolicy.GetMinimalAction())
+		case "mostDisruptiveAllowedAction":
+			return t.evaluate(updatePolicy.GetMostDisruptiveAllowedAction())
+		case "replacementMethod":
+			return t.evaluate(updatePolicy.GetReplacementMethod())
+		case "type":
+			return t.evaluate(updatePolicy.GetType())
+		default:
+			return false, fmt.Errorf("unknown updatePolicy key %v", t.AttributeKey)
+		}
+	case "versions":
+		// e.g. versions.instanceTemplate:*canary, versions.targetSize.fixed>=1
+		return filterRepeated(t, g.instanceGroupManager.GetVersions(), func(t term, version *computepb.InstanceGroupManagerVersion) (bool, error) {
+			switch t.Key {
+			case "instanceTemplate":
+				return t.evaluate(version.GetInstanceTemplate())
+			case "name":
+				return t.evaluate(version.GetName())
+			case "targetSize":
+				switch t.AttributeKey {
+				case "calculated":
+					return t.evaluate(strconv.FormatInt(int64(version.GetTargetSize().GetCalculated()), 10))
+				case "fixed":
+					return t.evaluate(strconv.FormatInt(int64(version.GetTargetSize().GetFixed()), 10))
+				case "percent":
+					return t.evaluate(strconv.FormatInt(int64(version.GetTargetSize().GetPercent()), 10))
+				default:
+					return false, fmt.Errorf("unknown targetSize key %v", t.AttributeKey)
+				}
+			default:
+				return false, fmt.Errorf("unknown versions key %v", t.Key)
+			}
+		})
+	case "zone":
+		return t.evaluate(g.instanceGroupManager.GetZone())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterInstanceGroupManagers filters the given managed instance groups according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroupManagers/aggregatedList
+func FilterInstanceGroupManagers(instanceGroupManagers []*computepb.InstanceGroupManager, gcpFilter string) ([]*computepb.InstanceGroupManager, error) {
+	return filterResources(instanceGroupManagers, gcpFilter, func(instanceGroupManager *computepb.InstanceGroupManager) gcpInstanceGroupManager {
+		return gcpInstanceGroupManager{instanceGroupManager: instanceGroupManager}
+	})
+}
@@ -0,0 +1,188 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type instanceGroupManagersArray []*computepb.InstanceGroupManager
+
+func (i instanceGroupManagersArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, instanceGroupManager := range i {
+		sb.WriteString(instanceGroupManager.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterInstanceGroupManagers(t *testing.T) {
+	instanceGroupManagers := instanceGroupManagersArray{
+		{
+			AutoHealingPolicies: []*computepb.InstanceGroupManagerAutoHealingPolicy{
+				{
+					HealthCheck:     toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/healthChecks/http-basic-check"),
+					InitialDelaySec: toInt32Ptr(300),
+				},
+			},
+			BaseInstanceName:  toStringPtr("web"),
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			CurrentActions: &computepb.InstanceGroupManagerActionsSummary{
+				Creating: toInt32Ptr(3),
+				None:     toInt32Ptr(9),
+			},
+			Id:               toUint64Ptr(2123462547295123412),
+			InstanceTemplate: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/instanceTemplates/web-template-v2"),
+			Kind:             toStringPtr("compute#instanceGroupManager"),
+			Name:             toStringPtr("web-mig"),
+			NamedPorts: []*computepb.NamedPort{
+				{
+					Name: toStringPtr("http"),
+					Port: toInt32Ptr(80),
+				},
+			},
+			Status: &computepb.InstanceGroupManagerStatus{
+				Autoscaler: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/autoscalers/web-autoscaler"),
+				IsStable:   toBoolPtr(false),
+				VersionTarget: &computepb.InstanceGroupManagerStatusVersionTarget{
+					IsReached: toBoolPtr(false),
+				},
+			},
+			TargetSize: toInt32Ptr(12),
+			UpdatePolicy: &computepb.InstanceGroupManagerUpdatePolicy{
+				Type:          toStringPtr("PROACTIVE"),
+				MinimalAction: toStringPtr("REPLACE"),
+				MaxSurge: &computepb.FixedOrPercent{
+					Fixed: toInt32Ptr(3),
+				},
+			},
+			Versions: []*computepb.InstanceGroupManagerVersion{
+				{
+					Name:             toStringPtr("stable"),
+					InstanceTemplate: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/instanceTemplates/web-template-v1"),
+				},
+				{
+					Name:             toStringPtr("canary"),
+					InstanceTemplate: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/instanceTemplates/web-template-v2"),
+					TargetSize: &computepb.FixedOrPercent{
+						Percent: toInt32Ptr(20),
+					},
+				},
+			},
+			Zone: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b"),
+		},
+		{
+			BaseInstanceName:  toStringPtr("db"),
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			InstanceTemplate:  toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/instanceTemplates/db"),
+			Kind:              toStringPtr("compute#instanceGroupManager"),
+			Name:              toStringPtr("db-mig"),
+			Region:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status: &computepb.InstanceGroupManagerStatus{
+				IsStable: toBoolPtr(true),
+				Stateful: &computepb.InstanceGroupManagerStatusStateful{
+					HasStatefulConfig: toBoolPtr(true),
+				},
+				VersionTarget: &computepb.InstanceGroupManagerStatusVersionTarget{
+					IsReached: toBoolPtr(true),
+				},
+			},
+			TargetSize: toInt32Ptr(3),
+			Versions: []*computepb.InstanceGroupManagerVersion{
+				{
+					InstanceTemplate: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/instanceTemplates/db"),
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                      string
+		args                      args
+		wantInstanceGroupManagers instanceGroupManagersArray
+		wantErr                   bool
+	}{
+		{
+			name: "Large unstable groups",
+			args: args{
+				gcpFilter: `targetSize>10 status.isStable=false`,
+			},
+			wantInstanceGroupManagers: instanceGroupManagersArray{
+				instanceGroupManagers[0],
+			},
+		},
+		{
+			name: "Canary versions",
+			args: args{
+				gcpFilter: `versions.name=canary AND versions.targetSize.percent>=20 status.versionTarget.isReached=false updatePolicy.maxSurge.fixed=3`,
+			},
+			wantInstanceGroupManagers: instanceGroupManagersArray{
+				instanceGroupManagers[0],
+			},
+		},
+		{
+			name: "Stateful regional groups",
+			args: args{
+				gcpFilter: `status.stateful.hasStatefulConfig=true region:* -status.autoscaler:* -autoHealingPolicies:*`,
+			},
+			wantInstanceGroupManagers: instanceGroupManagersArray{
+				instanceGroupManagers[1],
+			},
+		},
+		{
+			name: "Current actions and named ports",
+			args: args{
+				gcpFilter: `currentActions.creating>0 namedPorts.port=80 autoHealingPolicies.initialDelaySec>=300`,
+			},
+			wantInstanceGroupManagers: instanceGroupManagersArray{
+				instanceGroupManagers[0],
+			},
+		},
+		{
+			name: "Unknown status key",
+			args: args{
+				gcpFilter: `status.stateful.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInstanceGroupManagers, err := FilterInstanceGroupManagers(instanceGroupManagers, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterInstanceGroupManagers() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotInstanceGroupManagersArray := instanceGroupManagersArray(gotInstanceGroupManagers)
+			if !reflect.DeepEqual(gotInstanceGroupManagersArray, tt.wantInstanceGroupManagers) {
+				t.Errorf("FilterInstanceGroupManagers(): \"%v\". want: \"%v\"", gotInstanceGroupManagersArray, tt.wantInstanceGroupManagers)
+			}
+			t.Log(gotInstanceGroupManagersArray)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpInstanceGroup struct {
+	instanceGroup *computepb.InstanceGroup
+}
+
+func (g gcpInstanceGroup) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.instanceGroup.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.instanceGroup.GetDescription())
+	case "fingerprint":
+		return t.evaluate(g.instanceGroup.GetFingerprint())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.instanceGroup.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.instanceGroup.GetKind())
+	case "name":
+		return t.evaluate(g.instanceGroup.GetName())
+	case "namedPorts":
+		return filterRepeated(t, g.instanceGroup.GetNamedPorts(), filterNamedPort)
+	case "network":
+		return t.evaluate(g.instanceGroup.GetNetwork())
+	case "region":
+		return t.evaluate(g.instanceGroup.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.instanceGroup.GetSelfLink())
+	case "size":
+		return t.evaluate(strconv.FormatInt(int64(g.instanceGroup.GetSize()), 10))
+	case "subnetwork":
+		return t.evaluate(g.instanceGroup.GetSubnetwork())
+	case "zone":
+		return t.evaluate(g.instanceGroup.GetZone())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterNamedPort(t term, namedPort *computepb.NamedPort) (bool, error) {
+	switch t.Key {
+	case "name":
+		return t.evaluate(namedPort.GetName())
+	case "port":
+		return t.evaluate(strconv.FormatInt(int64(namedPort.GetPort()), 10))
+	default:
+		return false, fmt.Errorf("unknown namedPorts key %v", t.Key)
+	}
+}
+
+// FilterInstanceGroups filters the given instance groups according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroups/aggregatedList
+func FilterInstanceGroups(instanceGroups []*computepb.InstanceGroup, gcpFilter string) ([]*computepb.InstanceGroup, error) {
+	return filterResources(instanceGroups, gcpFilter, func(instanceGroup *computepb.InstanceGroup) gcpInstanceGroup {
+		return gcpInstanceGroup{instanceGroup: instanceGroup}
+	})
+}
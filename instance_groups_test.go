@@ -0,0 +1,117 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type instanceGroupsArray []*computepb.InstanceGroup
+
+func (i instanceGroupsArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, instanceGroup := range i {
+		sb.WriteString(instanceGroup.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterInstanceGroups(t *testing.T) {
+	instanceGroups := instanceGroupsArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#instanceGroup"),
+			Name:              toStringPtr("web-mig"),
+			NamedPorts: []*computepb.NamedPort{
+				{
+					Name: toStringPtr("http"),
+					Port: toInt32Ptr(80),
+				},
+			},
+			Network: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default"),
+			Size:    toInt32Ptr(12),
+			Zone:    toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b"),
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#instanceGroup"),
+			Name:              toStringPtr("udp-a"),
+			Network:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			Size:              toInt32Ptr(0),
+			Zone:              toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-c"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantInstanceGroups instanceGroupsArray
+		wantErr            bool
+	}{
+		{
+			name: "Named ports",
+			args: args{
+				gcpFilter: `namedPorts.name=http AND size>0`,
+			},
+			wantInstanceGroups: instanceGroupsArray{
+				instanceGroups[0],
+			},
+		},
+		{
+			name: "Empty groups",
+			args: args{
+				gcpFilter: `size=0 -namedPorts:* zone:*europe-west1-c`,
+			},
+			wantInstanceGroups: instanceGroupsArray{
+				instanceGroups[1],
+			},
+		},
+		{
+			name: "Unknown namedPorts key",
+			args: args{
+				gcpFilter: `namedPorts.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInstanceGroups, err := FilterInstanceGroups(instanceGroups, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterInstanceGroups() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotInstanceGroupsArray := instanceGroupsArray(gotInstanceGroups)
+			if !reflect.DeepEqual(gotInstanceGroupsArray, tt.wantInstanceGroups) {
+				t.Errorf("FilterInstanceGroups(): \"%v\". want: \"%v\"", gotInstanceGroupsArray, tt.wantInstanceGroups)
+			}
+			t.Log(gotInstanceGroupsArray)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpInstanceTemplate struct {
+	instanceTemplate *computepb.InstanceTemplate
+}
+
+func (g gcpInstanceTemplate) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.instanceTemplate.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.instanceTemplate.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.instanceTemplate.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.instanceTemplate.GetKind())
+	case "name":
+		return t.evaluate(g.instanceTemplate.GetName())
+	case "properties":
+		// e.g. properties.machineType:n2-*, properties.scheduling.preemptible=true
+		return filterInstanceProperties(t.descend(), g.instanceTemplate.GetProperties())
+	case "region":
+		return t.evaluate(g.instanceTemplate.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.instanceTemplate.GetSelfLink())
+	case "sourceInstance":
+		return t.evaluate(g.instanceTemplate.GetSourceInstance())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterInstanceProperties(t term, properties *computepb.InstanceProperties) (bool, error) {
+	switch t.Key {
+	case "canIpForward":
+		return t.evaluate(strconv.FormatBool(properties.GetCanIpForward()))
+	case "description":
+		return t.evaluate(properties.GetDescription())
+	case "disks":
+		return filterRepeated(t, properties.GetDisks(), filterAttachedDisk)
+	case "keyRevocationActionType":
+		return t.evaluate(properties.GetKeyRevocationActionType())
+	case "labels":
+		return t.evaluateLabels(properties.GetLabels())
+	case "machineType":
+		return t.evaluate(properties.GetMachineType())
+	case "minCpuPlatform":
+		return t.evaluate(properties.GetMinCpuPlatform())
+	case "networkInterfaces":
+		return filterRepeated(t, properties.GetNetworkInterfaces(), filterNetworkInterface)
+	case "privateIpv6GoogleAccess":
+		return t.evaluate(properties.GetPrivateIpv6GoogleAccess())
+	case "resourcePolicies":
+		return t.evaluateRepeated(properties.GetResourcePolicies())
+	case "scheduling":
+		return filterScheduling(t.descend(), properties.GetScheduling())
+	case "serviceAccounts":
+		return filterRepeated(t, properties.GetServiceAccounts(), filterServiceAccount)
+	case "tags":
+		const itemsKey = "items"
+		if itemsKey == t.AttributeKey {
+			return t.evaluateRepeated(properties.GetTags().GetItems())
+		}
+		return false, fmt.Errorf("unknown tags key %v", t.AttributeKey)
+	default:
+		return false, fmt.Errorf("unknown properties key %v", t.Key)
+	}
+}
+
+func filterAttachedDisk(t term, disk *computepb.AttachedDisk) (bool, error) {
+	switch t.Key {
+	case "autoDelete":
+		return t.evaluate(strconv.FormatBool(disk.GetAutoDelete()))
+	case "boot":
+		return t.evaluate(strconv.FormatBool(disk.GetBoot()))
+	case "deviceName":
+		return t.evaluate(disk.GetDeviceName())
+	case "diskSizeGb":
+		return t.evaluate(strconv.FormatInt(disk.GetDiskSizeGb(), 10))
+	case "initializeParams":
+		initializeParams := disk.GetInitializeParams()
+		switch t.AttributeKey {
+		case "diskName":
+			return t.evaluate(initializeParams.GetDiskName())
+		case "diskSizeGb":
+			return t.evaluate(strconv.FormatInt(initializeParams.GetDiskSizeGb(), 10))
+		case "diskType":
+			return t.evaluate(initializeParams.GetDiskType())
+		case "sourceImage":
+			return t.evaluate(initializeParams.GetSourceImage())
+		case "sourceSnapshot":
+			return t.evaluate(initializeParams.GetSourceSnapshot())
+		default:
+			return false, fmt.Errorf("unknown initializeParams key %v", t.AttributeKey)
+		}
+	case "interface":
+		return t.evaluate(disk.GetInterface())
+	case "mode":
+		return t.evaluate(disk.GetMode())
+	case "source":
+		return t.evaluate(disk.GetSource())
+	case "type":
+		return t.evaluate(disk.GetType())
+	default:
+		return false, fmt.Errorf("unknown disks key %v", t.Key)
+	}
+}
+
+func filterNetworkInterface(t term, networkInterface *computepb.NetworkInterface) (bool, error) {
+	switch t.Key {
+	case "accessConfigs":
+		// e.g. networkInterfaces.accessConfigs:*, networkInterfaces.accessConfigs.networkTier=PREMIUM
+		return filterRepeated(t, networkInterface.GetAccessConfigs(), func(t term, accessConfig *computepb.AccessConfig) (bool, error) {
+			switch t.Key {
+			case "name":
+				return t.evaluate(accessConfig.GetName())
+			case "natIP":
+				return t.evaluateIP(accessConfig.GetNatIP())
+			case "networkTier":
+				return t.evaluate(accessConfig.GetNetworkTier())
+			case "type":
+				return t.evaluate(accessConfig.GetType())
+			default:
+				return false, fmt.Errorf("unknown accessConfigs key %v", t.Key)
+			}
+		})
+	case "name":
+		return t.evaluate(networkInterface.GetName())
+	case "network":
+		return t.evaluate(networkInterface.GetNetwork())
+	case "networkIP":
+		return t.evaluateIP(networkInterface.GetNetworkIP())
+	case "nicType":
+		return t.evaluate(networkInterface.GetNicType())
+	case "stackType":
+		return t.evaluate(networkInterface.GetStackType())
+	case "subnetwork":
+		return t.evaluate(networkInterface.GetSubnetwork())
+	default:
+		return false, fmt.Errorf("unknown networkInterfaces key %v", t.Key)
+	}
+}
+
+func filterScheduling(t term, scheduling *computepb.Scheduling) (bool, error) {
+	switch t.Key {
+	case "automaticRestart":
+		return t.evaluate(strconv.FormatBool(scheduling.GetAutomaticRestart()))
+	case "instanceTerminationAction":
+		return t.evaluate(scheduling.GetInstanceTerminationAction())
+	case "onHostMaintenance":
+		return t.evaluate(scheduling.GetOnHostMaintenance())
+	case "preemptible":
+		return t.evaluate(strconv.FormatBool(scheduling.GetPreemptible()))
+	case "provisioningModel":
+		return t.evaluate(scheduling.GetProvisioningModel())
+	default:
+		return false, fmt.Errorf("unknown scheduling key %v", t.Key)
+	}
+}
+
+func filterServiceAccount(t term, serviceAccount *computepb.ServiceAccount) (bool, error) {
+	switch t.Key {
+	case "email":
+		return t.evaluate(serviceAccount.GetEmail())
+	case "scopes":
+		return t.evaluateRepeated(serviceAccount.GetScopes())
+	default:
+		return false, fmt.Errorf("unknown serviceAccounts key %v", t.Key)
+	}
+}
+
+// FilterInstanceTemplates filters the given instance templates according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/instanceTemplates/aggregatedList
+//  2. The nested keys of the properties are supported e.g. properties.disks.initializeParams.sourceImage
+func FilterInstanceTemplates(instanceTemplates []*computepb.InstanceTemplate, gcpFilter string) ([]*computepb.InstanceTemplate, error) {
+	return filterResources(instanceTemplates, gcpFilter, func(instanceTemplate *computepb.InstanceTemplate) gcpInstanceTemplate {
+		return gcpInstanceTemplate{instanceTemplate: instanceTemplate}
+	})
+}
@@ -0,0 +1,192 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type instanceTemplatesArray []*computepb.InstanceTemplate
+
+func (i instanceTemplatesArray) String() string {
+	if len(i) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, instanceTemplate := range i {
+		sb.WriteString(instanceTemplate.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterInstanceTemplates(t *testing.T) {
+	instanceTemplates := instanceTemplatesArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#instanceTemplate"),
+			Name:              toStringPtr("web-template-v1"),
+			Properties: &computepb.InstanceProperties{
+				Disks: []*computepb.AttachedDisk{
+					{
+						AutoDelete: toBoolPtr(true),
+						Boot:       toBoolPtr(true),
+						InitializeParams: &computepb.AttachedDiskInitializeParams{
+							DiskSizeGb:  toInt64Ptr(20),
+							DiskType:    toStringPtr("pd-balanced"),
+							SourceImage: toStringPtr("projects/debian-cloud/global/images/family/debian-12"),
+						},
+					},
+				},
+				Labels: map[string]string{
+					"app": "web",
+				},
+				MachineType: toStringPtr("n2-standard-4"),
+				NetworkInterfaces: []*computepb.NetworkInterface{
+					{
+						Network: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default"),
+						AccessConfigs: []*computepb.AccessConfig{
+							{
+								Name:        toStringPtr("External NAT"),
+								NetworkTier: toStringPtr("PREMIUM"),
+								Type:        toStringPtr("ONE_TO_ONE_NAT"),
+							},
+						},
+					},
+				},
+				Scheduling: &computepb.Scheduling{
+					AutomaticRestart:  toBoolPtr(true),
+					OnHostMaintenance: toStringPtr("MIGRATE"),
+					ProvisioningModel: toStringPtr("STANDARD"),
+				},
+				ServiceAccounts: []*computepb.ServiceAccount{
+					{
+						Email:  toStringPtr("default"),
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &computepb.Tags{
+					Items: []string{"http-server", "https-server"},
+				},
+			},
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#instanceTemplate"),
+			Name:              toStringPtr("batch-spot"),
+			Properties: &computepb.InstanceProperties{
+				Disks: []*computepb.AttachedDisk{
+					{
+						Boot: toBoolPtr(true),
+						InitializeParams: &computepb.AttachedDiskInitializeParams{
+							DiskSizeGb:  toInt64Ptr(100),
+							SourceImage: toStringPtr("projects/cos-cloud/global/images/family/cos-stable"),
+						},
+					},
+					{
+						Boot: toBoolPtr(false),
+						Type: toStringPtr("SCRATCH"),
+					},
+				},
+				MachineType: toStringPtr("e2-standard-8"),
+				NetworkInterfaces: []*computepb.NetworkInterface{
+					{
+						Network: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+					},
+				},
+				Scheduling: &computepb.Scheduling{
+					Preemptible:               toBoolPtr(true),
+					ProvisioningModel:         toStringPtr("SPOT"),
+					InstanceTerminationAction: toStringPtr("DELETE"),
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		wantInstanceTemplates instanceTemplatesArray
+		wantErr               bool
+	}{
+		{
+			name: "Machine type and labels",
+			args: args{
+				gcpFilter: `properties.machineType:n2-* properties.labels.app=web properties.tags.items:http-server`,
+			},
+			wantInstanceTemplates: instanceTemplatesArray{
+				instanceTemplates[0],
+			},
+		},
+		{
+			name: "Spot VMs",
+			args: args{
+				gcpFilter: `properties.scheduling.preemptible=true AND properties.scheduling.provisioningModel=SPOT`,
+			},
+			wantInstanceTemplates: instanceTemplatesArray{
+				instanceTemplates[1],
+			},
+		},
+		{
+			name: "Disks and network interfaces",
+			args: args{
+				gcpFilter: `properties.disks.initializeParams.diskSizeGb>50 properties.disks.type=SCRATCH -properties.networkInterfaces.accessConfigs:*`,
+			},
+			wantInstanceTemplates: instanceTemplatesArray{
+				instanceTemplates[1],
+			},
+		},
+		{
+			name: "Service accounts and source images",
+			args: args{
+				gcpFilter: `properties.serviceAccounts.scopes:*cloud-platform OR properties.disks.initializeParams.sourceImage:*debian-*`,
+			},
+			wantInstanceTemplates: instanceTemplatesArray{
+				instanceTemplates[0],
+			},
+		},
+		{
+			name: "Unknown properties key",
+			args: args{
+				gcpFilter: `properties.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInstanceTemplates, err := FilterInstanceTemplates(instanceTemplates, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterInstanceTemplates() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotInstanceTemplatesArray := instanceTemplatesArray(gotInstanceTemplates)
+			if !reflect.DeepEqual(gotInstanceTemplatesArray, tt.wantInstanceTemplates) {
+				t.Errorf("FilterInstanceTemplates(): \"%v\". want: \"%v\"", gotInstanceTemplatesArray, tt.wantInstanceTemplates)
+			}
+			t.Log(gotInstanceTemplatesArray)
+		})
+	}
+}
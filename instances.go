@@ -34,7 +34,7 @@ func (g gcpInstance) filterTerm(t term) (bool, error) {
 	case "cpuPlatform":
 		return t.evaluate(g.instance.GetCpuPlatform())
 	case "creationTimestamp":
-		return t.evaluate(g.instance.GetCreationTimestamp())
+		return t.evaluateTimestampString(g.instance.GetCreationTimestamp())
 	case "deletionProtection":
 		return t.evaluate(strconv.FormatBool(g.instance.GetDeletionProtection()))
 	case "description":
@@ -70,9 +70,9 @@ func (g gcpInstance) filterTerm(t term) (bool, error) {
 		}
 		return false, nil
 	case "lastStartTimestamp":
-		return t.evaluate(g.instance.GetLastStartTimestamp())
+		return t.evaluateTimestampString(g.instance.GetLastStartTimestamp())
 	case "lastStopTimestamp":
-		return t.evaluate(g.instance.GetLastStopTimestamp())
+		return t.evaluateTimestampString(g.instance.GetLastStopTimestamp())
 	case "machineType":
 		return t.evaluate(g.instance.GetMachineType())
 	case "name":
@@ -113,21 +113,7 @@ func (g gcpInstance) filterTerm(t term) (bool, error) {
 // Notes:
 //  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/instances/aggregatedList
 func FilterInstances(instances []*computepb.Instance, gcpFilter string) ([]*computepb.Instance, error) {
-	filteredInstances := make([]*computepb.Instance, 0, len(instances))
-	for _, instance := range instances {
-		resource := resource[gcpInstance]{
-			gcpResource: gcpInstance{
-				instance: instance,
-			},
-			gcpFilter: gcpFilter,
-		}
-		keepInstance, err := resource.filter()
-		if err != nil {
-			return nil, err
-		}
-		if keepInstance {
-			filteredInstances = append(filteredInstances, instance)
-		}
-	}
-	return filteredInstances, nil
+	return filterResources(instances, gcpFilter, func(instance *computepb.Instance) gcpInstance {
+		return gcpInstance{instance: instance}
+	})
 }
@@ -46,6 +46,7 @@ func TestFilterInstances(t *testing.T) {
 	instances := instancesArray{
 		{
 			Name:         toStringPtr("purple-gateway"),
+			Id:           toUint64Ptr(1360066178417571791),
 			CanIpForward: toBoolPtr(false),
 			Scheduling: &computepb.Scheduling{
 				OnHostMaintenance: toStringPtr("MIGRATE"),
@@ -63,6 +64,7 @@ func TestFilterInstances(t *testing.T) {
 		},
 		{
 			Name:         toStringPtr("blue-gateway"),
+			Id:           toUint64Ptr(1360066178417571792),
 			CanIpForward: toBoolPtr(false),
 			Scheduling: &computepb.Scheduling{
 				OnHostMaintenance: toStringPtr("MIGRATE"),
@@ -113,6 +115,43 @@ func TestFilterInstances(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Last start timestamp with offset",
+			args: args{
+				gcpFilter: `lastStartTimestamp>"2020-08-13T14:00:00Z"`,
+			},
+			wantInstances: instancesArray{
+				instances[0],
+			},
+		},
+		{
+			name: "Last start timestamp with dates",
+			args: args{
+				gcpFilter: `lastStartTimestamp<2021 AND lastStartTimestamp>="2020-08" AND lastStartTimestamp:2020-08-13*`,
+			},
+			wantInstances: instancesArray{
+				instances[0],
+				instances[1],
+			},
+		},
+		{
+			name: "Exact uint64 ids",
+			args: args{
+				gcpFilter: `id=1360066178417571791`,
+			},
+			wantInstances: instancesArray{
+				instances[0],
+			},
+		},
+		{
+			name: "Exact uint64 ids comparison",
+			args: args{
+				gcpFilter: `id>1360066178417571791 AND id:(1360066178417571792 1360066178417571793)`,
+			},
+			wantInstances: instancesArray{
+				instances[1],
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
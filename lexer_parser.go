@@ -17,9 +17,12 @@
 package gcloudfilter
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -28,11 +31,13 @@ import (
 
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var parser = participle.MustBuild[grammar](
 	participle.Lexer(lexer.MustSimple([]lexer.SimpleRule{
-		{Name: "Ident", Pattern: `-?[a-zA-Z_\*-]+|\*`},
+		{Name: "Ident", Pattern: `-?[a-zA-Z_][a-zA-Z0-9_\*-]*|-?[a-zA-Z_\*-]+|\*`},
 		{Name: "List", Pattern: `\([^\(^\)]*\)`},
 		{Name: "QuotedLiteral", Pattern: `"[^"]*"|'[^']*'`},
 		{Name: "FloatingPointNumericConstant", Pattern: `[-+]?(\d+\.\d*|\.\d+)([eE][-+]?\d+)?`},
@@ -92,13 +97,13 @@ func (l *list) Capture(v []string) error {
 		if (token[0] == '"' && token[len(token)-1] == '"') || (token[0] == '\'' && token[len(token)-1] == '\'') {
 			// Single or double quoted literal
 			literal := token[1 : len(token)-1]
-			l.Values = append(l.Values, value{Literal: &literal})
+			l.Values = append(l.Values, value{Literal: &literal, text: literal})
 		} else if number, err := strconv.ParseFloat(token, 64); err == nil {
 			// Number
-			l.Values = append(l.Values, value{Number: &number})
+			l.Values = append(l.Values, value{Number: &number, text: token})
 		} else {
 			// Unquoted literal
-			l.Values = append(l.Values, value{Literal: &token})
+			l.Values = append(l.Values, value{Literal: &token, text: token})
 		}
 	}
 	return nil
@@ -114,7 +119,7 @@ func (b *boolean) Capture(values []string) error {
 type term struct {
 	Negation            bool     `parser:"((@'NOT'?"                                                                 json:"negation,omitempty"`
 	Key                 string   `parser:"@Ident"                                                                    json:"key,omitempty"`
-	AttributeKey        string   `parser:"('.' @Ident)?)!"                                                           json:"attribute-key,omitempty"`
+	AttributeKey        string   `parser:"('.' @(Ident ('.' Ident)*))?)!"                                            json:"attribute-key,omitempty"`
 	Operator            string   `parser:"@(':' | '=' | '!=' | '<' | '<=' | '>=' | '>' | '~' | 'eq' | '!~' | 'ne')!" json:"operator,omitempty"`
 	ValuesList          *list    `parser:"(@List"                                                                    json:"values,omitempty"`
 	Value               *value   `parser:"| @@)!"                                                                    json:"value,omitempty"`
@@ -122,14 +127,19 @@ type term struct {
 	LogicalOperator     string   `parser:"@('AND' | 'OR')?"                                                          json:"logical-operator,omitempty"`
 }
 
-func (t term) evaluateTimestamp(projectTimeStr string) (bool, error) {
+// filterValues returns the value or the values' list of the term
+func (t term) filterValues() []value {
 	filterValues := make([]value, 0, 1)
 	if t.Value != nil {
 		filterValues = append(filterValues, *t.Value)
 	} else if t.ValuesList != nil {
 		filterValues = t.ValuesList.Values
 	}
+	return filterValues
+}
 
+func (t term) evaluateTimestamp(projectTimeStr string) (bool, error) {
+	filterValues := t.filterValues()
 	projectValue := value{Literal: &projectTimeStr}
 
 	var result bool
@@ -151,24 +161,108 @@ func (t term) evaluateTimestamp(projectTimeStr string) (bool, error) {
 	return result, err
 }
 
+// evaluateTimestampString evaluates RFC3339 timestamps that may have any offset e.g. creationTimestamp
+// 2023-12-01T03:52:49.415-08:00 of compute resources. They are compared as times with RFC3339 time literals
+// e.g. creationTimestamp<"2024-01-01T00:00:00Z", dates e.g. creationTimestamp>=2023-12-01 or relative times
+// e.g. creationTimestamp<-P90D and as strings with patterns e.g. creationTimestamp:2023-12*
+func (t term) evaluateTimestampString(timestamp string) (bool, error) {
+	if timestamp == "" {
+		return t.evaluateUnset()
+	}
+	// Existence check e.g. deprecated.obsolete:*
+	if t.existence() {
+		return true, nil
+	}
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := t.evaluateTimestampString(timestamp)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+	if t.Operator == "~" {
+		return t.evaluate(timestamp)
+	}
+	resourceTime, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return false, err
+	}
+	for _, filterValue := range t.filterValues() {
+		var result bool
+		if filterTime, err := parseTime(filterValue.text); err == nil {
+			result, err = compareResult(t.Operator, resourceTime.Compare(filterTime))
+			if err != nil {
+				return false, err
+			}
+		} else if t.Operator == ":" {
+			patternTerm := t
+			patternTerm.Value = &filterValue
+			patternTerm.ValuesList = nil
+			if result, err = patternTerm.evaluate(timestamp); err != nil {
+				return false, err
+			}
+		} else {
+			return false, errors.New("timestamps can only be compared with RFC3339 times, dates or relative time literals")
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// dateLayouts are the layouts of the dates without a time e.g. 2024, 2024-01, 2024-01-15
+var dateLayouts = []string{time.DateOnly, "2006-01", "2006"}
+
+// parseTime parses RFC3339 times e.g. 2024-01-01T00:00:00Z, dates as their start in UTC e.g. 2024-01 and,
+// as gcloud, ISO 8601 durations relative to the current time e.g. -P90D is 90 days ago and P1W one week from now
+func parseTime(timeStr string) (time.Time, error) {
+	parsedTime, err := time.Parse(time.RFC3339, timeStr)
+	if err == nil {
+		return parsedTime, nil
+	}
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimPrefix(timeStr, "-")), "P") {
+		for _, layout := range dateLayouts {
+			if parsedTime, dateErr := time.Parse(layout, timeStr); dateErr == nil {
+				return parsedTime, nil
+			}
+		}
+		return time.Time{}, err
+	}
+	duration, durationErr := parseDuration(timeStr)
+	if durationErr != nil {
+		return time.Time{}, durationErr
+	}
+	return time.Now().Add(duration), nil
+}
+
 func (t term) evaluate(projectValueStr string) (bool, error) {
-	filterValues := make([]value, 0, 1)
-	if t.Value != nil {
-		filterValues = append(filterValues, *t.Value)
-	} else if t.ValuesList != nil {
-		filterValues = t.ValuesList.Values
+	// Existence check e.g. description:*
+	if t.existence() {
+		return projectValueStr != "", nil
 	}
 
+	filterValues := t.filterValues()
 	var result bool
 	var err error
 	for _, filterValue := range filterValues {
 		var projectValue value
-		if filterValue.Number != nil {
+		// Unset values e.g. an empty oauth2ClientId do not match any number
+		if filterValue.Number != nil && projectValueStr != "" {
 			number, err := strconv.ParseFloat(projectValueStr, 64)
 			if err != nil {
 				return false, err
 			}
 			projectValue.Number = &number
+			// Integers are compared exactly as float64 loses the precision of the ones above 2^53
+			// e.g. id=1360066178417571791
+			if projectInteger, ok := parseInteger(projectValueStr); ok {
+				if filterInteger, ok := parseInteger(filterValue.text); ok {
+					projectValue.integer = projectInteger
+					filterValue.integer = filterInteger
+				}
+			}
 		} else {
 			projectValue.Literal = &projectValueStr
 		}
@@ -180,6 +274,453 @@ func (t term) evaluate(projectValueStr string) (bool, error) {
 	return result, err
 }
 
+// evaluateTime evaluates time.Time values. The zero time is treated as unset
+func (t term) evaluateTime(resourceTime time.Time) (bool, error) {
+	if resourceTime.IsZero() {
+		return t.evaluateTimestampString("")
+	}
+	return t.evaluateTimestampString(resourceTime.Format(time.RFC3339Nano))
+}
+
+// evaluateEpochMilliseconds evaluates times given in milliseconds since the epoch e.g. creationTime of the
+// BigQuery resources. They are compared with RFC3339 times or, if all the filter values are numbers, with
+// milliseconds e.g. creationTime>1700000000000. Zero is a time too e.g. expirationTime=0 unless unset
+func (t term) evaluateEpochMilliseconds(milliseconds int64, set bool) (bool, error) {
+	if !set {
+		return t.evaluateUnset()
+	}
+	numeric := true
+	for _, filterValue := range t.filterValues() {
+		numeric = numeric && filterValue.Number != nil
+	}
+	if numeric {
+		return t.evaluate(strconv.FormatInt(milliseconds, 10))
+	}
+	return t.evaluateTime(time.UnixMilli(milliseconds).UTC())
+}
+
+// evaluateProtoTimestamp evaluates protobuf timestamps. A nil timestamp is treated as unset
+func (t term) evaluateProtoTimestamp(timestamp *timestamppb.Timestamp) (bool, error) {
+	if timestamp == nil {
+		return t.evaluateTime(time.Time{})
+	}
+	return t.evaluateTime(timestamp.AsTime())
+}
+
+// evaluateDuration evaluates durations against durations in the gcloud e.g. 1h30m, 7d or ISO 8601 e.g. P7D,
+// PT10M30S forms or seconds e.g. 600
+func (t term) evaluateDuration(duration time.Duration) (bool, error) {
+	// Existence check e.g. expirationPolicy.ttl:*
+	if t.existence() {
+		return true, nil
+	}
+	projectValue := value{duration: &duration}
+	for _, filterValue := range t.filterValues() {
+		filterDuration, err := parseDuration(filterValue.text)
+		if err != nil {
+			return false, err
+		}
+		filterValue.duration = &filterDuration
+		result, err := projectValue.compare(t.Operator, filterValue)
+		if result || err != nil {
+			return result, err
+		}
+	}
+	return false, nil
+}
+
+// evaluateProtoDuration evaluates protobuf durations. A nil duration is treated as unset
+func (t term) evaluateProtoDuration(duration *durationpb.Duration) (bool, error) {
+	if duration == nil {
+		return t.evaluateUnset()
+	}
+	return t.evaluateDuration(duration.AsDuration())
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^(?i)P(?:(\d+(?:\.\d+)?)Y)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// The units of the ISO 8601 durations. A year is 365 days and a month 30 days
+var isoDurationUnits = [...]time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+var gcloudDurationRegexp = regexp.MustCompile(`^(?i)(?:(\d+(?:\.\d+)?)d)?(?:(\d+(?:\.\d+)?)h)?(?:(\d+(?:\.\d+)?)m)?(?:(\d+(?:\.\d+)?)s)?$`)
+
+// The units of the gcloud durations
+var gcloudDurationUnits = [...]time.Duration{
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// parseDuration parses durations in the gcloud e.g. 1h30m, 7d or ISO 8601 e.g. P7D, PT10M30S forms or
+// seconds e.g. 600. See https://cloud.google.com/sdk/gcloud/reference/topic/datetimes
+func parseDuration(durationStr string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(durationStr, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	sign := time.Duration(1)
+	if strings.HasPrefix(durationStr, "-") {
+		sign = -1
+		durationStr = durationStr[1:]
+	}
+	if matches := isoDurationRegexp.FindStringSubmatch(durationStr); matches != nil &&
+		!strings.EqualFold(durationStr, "P") && !strings.HasSuffix(strings.ToUpper(durationStr), "T") {
+		duration, err := sumDuration(matches[1:], isoDurationUnits[:])
+		return sign * duration, err
+	}
+	if matches := gcloudDurationRegexp.FindStringSubmatch(durationStr); matches != nil && durationStr != "" {
+		duration, err := sumDuration(matches[1:], gcloudDurationUnits[:])
+		return sign * duration, err
+	}
+	// e.g. 1.5h, 300ms
+	if duration, err := time.ParseDuration(durationStr); err == nil {
+		return sign * duration, nil
+	}
+	return 0, fmt.Errorf("invalid duration %v", durationStr)
+}
+
+// sumDuration sums the amounts of the given units e.g. [1 30] of [time.Hour time.Minute] => 1h30m
+func sumDuration(amounts []string, units []time.Duration) (time.Duration, error) {
+	var duration time.Duration
+	for i, amountStr := range amounts {
+		if amountStr == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(amount * float64(units[i]))
+	}
+	return duration, nil
+}
+
+// evaluateIP evaluates IP addresses against IP addresses or CIDR ranges
+// e.g. address:10.0.0.0/8 is true if the address is inside the range, address>=10.0.0.5 compares the addresses
+// Other values and operators e.g. address:10.0.*, address~^10\. fall back to plain evaluation
+func (t term) evaluateIP(ipStr string) (bool, error) {
+	ip, err := netip.ParseAddr(ipStr)
+	if err != nil || t.existence() {
+		return t.evaluate(ipStr)
+	}
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := t.evaluateIP(ipStr)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+	ip = ip.Unmap()
+
+	for _, filterValue := range t.filterValues() {
+		var result bool
+		ordering := t.Operator == "<" || t.Operator == "<=" || t.Operator == ">=" || t.Operator == ">"
+		if prefix, err := netip.ParsePrefix(filterValue.text); err == nil && (t.Operator == ":" || t.Operator == "=") {
+			result = prefix.Masked().Contains(ip)
+		} else if filterIP, err := netip.ParseAddr(filterValue.text); err == nil && ordering {
+			result, err = compareResult(t.Operator, ip.Compare(filterIP.Unmap()))
+			if err != nil {
+				return false, err
+			}
+		} else {
+			filterTerm := t
+			filterTerm.Value = &filterValue
+			filterTerm.ValuesList = nil
+			result, err = filterTerm.evaluate(ipStr)
+			if err != nil {
+				return false, err
+			}
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateCIDR evaluates CIDR ranges against CIDR ranges or IP addresses e.g. clusterIpv4Cidr:10.0.0.0/8 is
+// true if the range is inside the given one, clusterIpv4Cidr:10.4.0.1 if the range contains the address
+// Other values e.g. clusterIpv4Cidr:10.4.* fall back to plain evaluation
+func (t term) evaluateCIDR(cidr string) (bool, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || t.existence() {
+		return t.evaluate(cidr)
+	}
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := t.evaluateCIDR(cidr)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+	prefix = prefix.Masked()
+
+	for _, filterValue := range t.filterValues() {
+		var result bool
+		containment := t.Operator == ":" || t.Operator == "="
+		if filterPrefix, err := netip.ParsePrefix(filterValue.text); err == nil && containment {
+			result = filterPrefix.Bits() <= prefix.Bits() && filterPrefix.Masked().Contains(prefix.Addr())
+		} else if filterIP, err := netip.ParseAddr(filterValue.text); err == nil && containment {
+			result = prefix.Contains(filterIP.Unmap())
+		} else {
+			filterTerm := t
+			filterTerm.Value = &filterValue
+			filterTerm.ValuesList = nil
+			result, err = filterTerm.evaluate(cidr)
+			if err != nil {
+				return false, err
+			}
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateVersion evaluates versions e.g. currentMasterVersion<1.28 is true for 1.27.3-gke.100
+// The versions are compared by the precedence of https://semver.org, with any number of numeric
+// components, therefore version>1.9 is true for 1.27 and 2.10.0-rc.1 is lower than 2.10.0. The : and
+// the regular expression operators as well as the non version values fall back to plain evaluation
+func (t term) evaluateVersion(versionStr string) (bool, error) {
+	version, ok := parseVersion(versionStr)
+	if !ok || !comparisonOperator(t.Operator) {
+		return t.evaluate(versionStr)
+	}
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := t.evaluateVersion(versionStr)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+
+	for _, filterValue := range t.filterValues() {
+		var result bool
+		var err error
+		if filterVersion, ok := parseVersion(filterValue.text); ok {
+			result, err = compareResult(t.Operator, version.compare(filterVersion))
+		} else {
+			filterTerm := t
+			filterTerm.Value = &filterValue
+			filterTerm.ValuesList = nil
+			result, err = filterTerm.evaluate(versionStr)
+		}
+		if err != nil {
+			return false, err
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// version is a version e.g. 1.27.3-gke.100 split into its numeric components and its pre-release
+// identifiers. The build metadata e.g. +build.5 is ignored as in https://semver.org
+type version struct {
+	components []int
+	preRelease []string
+}
+
+var versionRegexp = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// parseVersion parses versions e.g. 1.27.3-gke.100, v2.10.0-rc.1, 1.9
+func parseVersion(versionStr string) (version, bool) {
+	matches := versionRegexp.FindStringSubmatch(versionStr)
+	if matches == nil {
+		return version{}, false
+	}
+	var v version
+	for _, digits := range strings.Split(matches[1], ".") {
+		component, err := strconv.Atoi(digits)
+		if err != nil {
+			return version{}, false
+		}
+		v.components = append(v.components, component)
+	}
+	if matches[2] != "" {
+		v.preRelease = strings.Split(matches[2], ".")
+	}
+	return v, true
+}
+
+// compare compares the versions by their numeric components, the missing ones being zero e.g. 1.28 equals
+// 1.28.0, and then by their pre-release identifiers. A pre-release is lower than its release
+// e.g. 2.10.0-rc.1 < 2.10.0
+func (v version) compare(other version) int {
+	for i := 0; i < max(len(v.components), len(other.components)); i++ {
+		var component, otherComponent int
+		if i < len(v.components) {
+			component = v.components[i]
+		}
+		if i < len(other.components) {
+			otherComponent = other.components[i]
+		}
+		if component != otherComponent {
+			return cmp.Compare(component, otherComponent)
+		}
+	}
+	if len(v.preRelease) == 0 || len(other.preRelease) == 0 {
+		// The release is higher than its pre-releases
+		return cmp.Compare(len(other.preRelease), len(v.preRelease))
+	}
+	for i := 0; i < min(len(v.preRelease), len(other.preRelease)); i++ {
+		if comparison := comparePreReleaseIdentifiers(v.preRelease[i], other.preRelease[i]); comparison != 0 {
+			return comparison
+		}
+	}
+	return cmp.Compare(len(v.preRelease), len(other.preRelease))
+}
+
+// comparePreReleaseIdentifiers compares numeric identifiers numerically and the rest lexically. Numeric
+// identifiers are lower than the rest e.g. 1.0.0-alpha.1 < 1.0.0-alpha.beta
+func comparePreReleaseIdentifiers(identifier, otherIdentifier string) int {
+	number, err := strconv.ParseUint(identifier, 10, 64)
+	numeric := err == nil
+	otherNumber, err := strconv.ParseUint(otherIdentifier, 10, 64)
+	otherNumeric := err == nil
+	switch {
+	case numeric && otherNumeric:
+		return cmp.Compare(number, otherNumber)
+	case numeric:
+		return -1
+	case otherNumeric:
+		return 1
+	}
+	return strings.Compare(identifier, otherIdentifier)
+}
+
+// comparisonOperator reports whether the operator compares values i.e. it is not a pattern one
+func comparisonOperator(operator string) bool {
+	switch operator {
+	case "=", "!=", "<", "<=", ">=", ">":
+		return true
+	}
+	return false
+}
+
+// compareResult returns the result of the operator given the comparison of two values
+// i.e. -1, 0, +1 as returned by the Compare functions
+func compareResult(operator string, comparison int) (bool, error) {
+	switch operator {
+	case ":", "=":
+		return comparison == 0, nil
+	case "!=":
+		return comparison != 0, nil
+	case "<":
+		return comparison < 0, nil
+	case "<=":
+		return comparison <= 0, nil
+	case ">=":
+		return comparison >= 0, nil
+	case ">":
+		return comparison > 0, nil
+	}
+	return false, fmt.Errorf("invalid operator %v for comparison", operator)
+}
+
+// Negative operators and their positive counterparts
+var negativeOperators = map[string]string{
+	"!=": "=",
+	"!~": "~",
+	"ne": "~",
+}
+
+// evaluateRepeated evaluates a repeated field. It is true if any of the items matches.
+// Negative operators are true if none of the items matches e.g. tags!=foo
+func (t term) evaluateRepeated(items []string) (bool, error) {
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := t.evaluateRepeated(items)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+	// Existence check e.g. tags:*
+	if t.existence() {
+		return len(items) > 0, nil
+	}
+	for _, item := range items {
+		result, err := t.evaluate(item)
+		if result || err != nil {
+			return result, err
+		}
+	}
+	return false, nil
+}
+
+// evaluateLabels evaluates the label with key the attribute key of the term
+// e.g. labels.color:red, labels.color:*, -labels.color:red
+func (t term) evaluateLabels(labels map[string]string) (bool, error) {
+	labelValue, ok := labels[t.AttributeKey]
+	if !ok {
+		return false, nil
+	}
+	// Existence check
+	if t.existence() {
+		return true, nil
+	}
+	return t.evaluate(labelValue)
+}
+
+// descend returns the term of the nested attribute key e.g. properties.labels.color => labels.color
+func (t term) descend() term {
+	t.Key, t.AttributeKey, _ = strings.Cut(t.AttributeKey, ".")
+	return t
+}
+
+// filterRepeated filters the items of a repeated nested field e.g. disks.initializeParams.diskSizeGb>100
+// It is true if the nested term is true for any of the items. Negative operators are true if the
+// positive one is true for none of the items
+func filterRepeated[T any](t term, items []T, filterItem func(term, T) (bool, error)) (bool, error) {
+	if operator, ok := negativeOperators[t.Operator]; ok {
+		t.Operator = operator
+		result, err := filterRepeated(t, items, filterItem)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+	// Existence check e.g. disks:*
+	if t.AttributeKey == "" && t.existence() {
+		return len(items) > 0, nil
+	}
+	// Without items the nested term is false e.g. peerings.name=default for networks without peerings
+	if len(items) == 0 {
+		return false, nil
+	}
+	for _, item := range items {
+		result, err := filterItem(t.descend(), item)
+		if result || err != nil {
+			return result, err
+		}
+	}
+	return false, nil
+}
+
+// parseInteger parses base 10 integers of any size e.g. the uint64 ids of the compute resources
+func parseInteger(s string) (*big.Int, bool) {
+	return new(big.Int).SetString(s, 10)
+}
+
+// existence is true for existence checks e.g. labels.color:*
+func (t term) existence() bool {
+	return t.Operator == ":" && t.Value != nil && t.Value.Literal != nil && *t.Value.Literal == "*"
+}
+
 func (t term) unQuote() {
 	// Items in t.ValuesList are already unquoted from Capture
 	if t.Value != nil && t.Value.Literal != nil {
@@ -187,6 +728,7 @@ func (t term) unQuote() {
 		if (literal[0] == '"' && literal[len(literal)-1] == '"') || (literal[0] == '\'' && literal[len(literal)-1] == '\'') {
 			*t.Value.Literal = literal[1 : len(literal)-1]
 		}
+		t.Value.text = *t.Value.Literal
 	}
 }
 
@@ -208,6 +750,12 @@ func (t term) simplePattern() {
 type value struct {
 	Literal *string  `parser:"  @Ident | @QuotedLiteral"              json:"literal,omitempty"`
 	Number  *float64 `parser:"| @FloatingPointNumericConstant | @Int" json:"number,omitempty"`
+	// Unquoted text of the value as given in the filter. It is kept intact by simplePattern
+	text string
+	// Duration of the value if compared with durations e.g. messageRetentionDuration>P7D
+	duration *time.Duration
+	// Exact integer of the value if compared with integers e.g. id=1360066178417571791 that float64 cannot represent
+	integer *big.Int
 }
 
 func (v value) String() string {
@@ -223,7 +771,11 @@ func (v value) String() string {
 }
 
 func (v value) equal(filterValue value) bool {
-	if v.Literal != nil && filterValue.Literal != nil {
+	if v.duration != nil && filterValue.duration != nil {
+		return *v.duration == *filterValue.duration
+	} else if v.integer != nil && filterValue.integer != nil {
+		return v.integer.Cmp(filterValue.integer) == 0
+	} else if v.Literal != nil && filterValue.Literal != nil {
 		return strings.EqualFold(*v.Literal, *filterValue.Literal)
 	} else if v.Number != nil && filterValue.Number != nil {
 		return *v.Number == *filterValue.Number
@@ -232,7 +784,11 @@ func (v value) equal(filterValue value) bool {
 }
 
 func (v value) lessThan(filterValue value) bool {
-	if v.Literal != nil && filterValue.Literal != nil {
+	if v.duration != nil && filterValue.duration != nil {
+		return *v.duration < *filterValue.duration
+	} else if v.integer != nil && filterValue.integer != nil {
+		return v.integer.Cmp(filterValue.integer) < 0
+	} else if v.Literal != nil && filterValue.Literal != nil {
 		return *v.Literal < *filterValue.Literal
 	} else if v.Number != nil && filterValue.Number != nil {
 		return *v.Number < *filterValue.Number
@@ -241,7 +797,11 @@ func (v value) lessThan(filterValue value) bool {
 }
 
 func (v value) greaterThan(filterValue value) bool {
-	if v.Literal != nil && filterValue.Literal != nil {
+	if v.duration != nil && filterValue.duration != nil {
+		return *v.duration > *filterValue.duration
+	} else if v.integer != nil && filterValue.integer != nil {
+		return v.integer.Cmp(filterValue.integer) > 0
+	} else if v.Literal != nil && filterValue.Literal != nil {
 		return *v.Literal > *filterValue.Literal
 	} else if v.Number != nil && filterValue.Number != nil {
 		return *v.Number > *filterValue.Number
@@ -256,6 +816,8 @@ func (v value) matchRegExp(filterValue value, simplePattern bool) (bool, error)
 	}
 	if v.Literal != nil && filterValue.Literal != nil {
 		return regexp.MatchString(pattern+*filterValue.Literal, *v.Literal)
+	} else if v.integer != nil && filterValue.integer != nil {
+		return regexp.MatchString(pattern+regexp.QuoteMeta(filterValue.integer.String()), v.integer.String())
 	} else if v.Number != nil && filterValue.Number != nil {
 		filterValueNumber := regexp.QuoteMeta(fmt.Sprint(*filterValue.Number))
 		return regexp.MatchString(pattern+filterValueNumber, fmt.Sprint(*v.Number))
@@ -264,8 +826,14 @@ func (v value) matchRegExp(filterValue value, simplePattern bool) (bool, error)
 }
 
 func (v value) compare(operator string, filterValue value) (bool, error) {
+	if v.duration != nil && filterValue.duration != nil && !comparisonOperator(operator) && operator != ":" {
+		return false, fmt.Errorf("invalid operator %v for durations", operator)
+	}
 	switch operator {
 	case ":":
+		if v.duration != nil && filterValue.duration != nil {
+			return v.equal(filterValue), nil
+		}
 		// Case insensitive operator
 		return v.matchRegExp(filterValue, true)
 	case "=":
@@ -336,13 +904,18 @@ func isOperator(ch byte, operators []byte) bool {
 func wrapValuesWithParentheses(gcpFilter string) string {
 	var sb strings.Builder
 	sb.Grow(len(gcpFilter) + 64)
-	var quoted, operator, wrap bool
+	var quoted, list, operator, wrap bool
 	for i, ch := range gcpFilter {
 		if isOperator(gcpFilter[i], operators[:len(operators)-1]) {
 			operator = true
 			sb.WriteRune(ch)
-		} else if ch == '"' || ch == '\'' || ch == '(' {
-			// Mark to not do anything when quoted or parenthesized already
+		} else if ch == '(' || ch == ')' {
+			// Mark to not do anything when parenthesized already. Quotes inside lists are left as is
+			list = ch == '('
+			operator = false
+			sb.WriteRune(ch)
+		} else if (ch == '"' || ch == '\'') && !list {
+			// Mark to not do anything when quoted already
 			if quoted {
 				// End of quote
 				quoted = false
@@ -352,9 +925,9 @@ func wrapValuesWithParentheses(gcpFilter string) string {
 				quoted = true
 			}
 			sb.WriteRune(ch)
-		} else if operator {
+		} else if operator && !list {
 			// Inside AttributeValue
-			if ch == '*' {
+			if ch == '*' && !wrap && (i == len(gcpFilter)-1 || gcpFilter[i+1] == ' ') {
 				// No parentheses wrap in existense checks e.g. -labels.foo:*
 				sb.WriteRune(ch)
 				operator = false
@@ -409,7 +982,8 @@ func quoteStringValues(gcpFilter string) string {
 			continue
 		}
 
-		if isOperator(gcpFilter[i], operators[:]) {
+		// Operator characters inside a value being quoted are part of it e.g. members=user:alice@example.com
+		if !wrap && isOperator(gcpFilter[i], operators[:]) {
 			operator = true
 			sb.WriteRune(ch)
 		} else if operator {
@@ -493,6 +1067,26 @@ type resource[C resourcer] struct {
 	gcpFilter   string
 }
 
+// filterResources keeps the resources that match the gcpFilter. newResourcer wraps each
+// resource into its resourcer
+func filterResources[T any, C resourcer](resources []T, gcpFilter string, newResourcer func(T) C) ([]T, error) {
+	filteredResources := make([]T, 0, len(resources))
+	for _, gcpResource := range resources {
+		resource := resource[C]{
+			gcpResource: newResourcer(gcpResource),
+			gcpFilter:   gcpFilter,
+		}
+		keepResource, err := resource.filter()
+		if err != nil {
+			return nil, err
+		}
+		if keepResource {
+			filteredResources = append(filteredResources, gcpResource)
+		}
+	}
+	return filteredResources, nil
+}
+
 func (r resource[C]) filter() (bool, error) {
 	var keepProject bool
 	subGCPfilter, err := extractInnermostExpression(r.gcpFilter)
@@ -18,6 +18,8 @@ package gcloudfilter
 
 import (
 	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
 )
 
 func TestParse(t *testing.T) {
@@ -58,6 +60,48 @@ func TestParse(t *testing.T) {
 			},
 			want: `{"terms":[{"negation":true,"key":"labels","attribute-key":"volume","operator":":","value":{"literal":"*"},"logical-operator":"AND"},{"negation":true,"key":"labels","attribute-key":"c-ol_or","operator":":","value":{"literal":"*"}}]}`,
 		},
+		{
+			name: "Unquoted values after a list, digits in keys",
+			args: args{
+				gcpFilter: `labels.volume:("small" 'big') AND labels.ip:10.8.* labels.digit2:v2`,
+			},
+			want: `{"terms":[{"key":"labels","attribute-key":"volume","operator":":","values":{"values":[{"literal":"^small$"},{"literal":"^big$"}]},"logical-operator":"AND"},{"key":"labels","attribute-key":"ip","operator":":","values":{"values":[{"literal":"^10\\.8\\..*$"}]}},{"key":"labels","attribute-key":"digit2","operator":":","value":{"literal":"^v2$"}}]}`,
+		},
+		{
+			name: "Nested attribute keys",
+			args: args{
+				gcpFilter: `properties.scheduling.preemptible=true AND status.isStable:false`,
+			},
+			want: `{"terms":[{"key":"properties","attribute-key":"scheduling.preemptible","operator":"=","value":{"literal":"true"},"logical-operator":"AND"},{"key":"status","attribute-key":"isStable","operator":":","value":{"literal":"^false$"}}]}`,
+		},
+		{
+			name: "Quoted values in lists",
+			args: args{
+				gcpFilter: `name:("web server" 'db*' api) AND zone:us-east1-b`,
+			},
+			want: `{"terms":[{"key":"name","operator":":","values":{"values":[{"literal":"^web server$"},{"literal":"^db.*$"},{"literal":"^api$"}]},"logical-operator":"AND"},{"key":"zone","operator":":","value":{"literal":"^us-east1-b$"}}]}`,
+		},
+		{
+			name: "Digits in keys",
+			args: args{
+				gcpFilter: `gatewayIPv4:10.0.0.1 AND ipv6AccessType=EXTERNAL OR selfLink:*v1*`,
+			},
+			want: `{"terms":[{"key":"gatewayIPv4","operator":":","values":{"values":[{"literal":"^10.0.0.1$"}]},"logical-operator":"AND"},{"key":"ipv6AccessType","operator":"=","value":{"literal":"EXTERNAL"},"logical-operator":"OR"},{"key":"selfLink","operator":":","values":{"values":[{"literal":"^.*v1.*$"}]}}]}`,
+		},
+		{
+			name: "Existence checks, wildcards",
+			args: args{
+				gcpFilter: `peerings:* AND -description:* name:web* OR description=*`,
+			},
+			want: `{"terms":[{"key":"peerings","operator":":","value":{"literal":"*"},"logical-operator":"AND"},{"negation":true,"key":"description","operator":":","value":{"literal":"*"}},{"key":"name","operator":":","value":{"literal":"^web.*$"},"logical-operator":"OR"},{"key":"description","operator":"=","value":{"literal":"*"}}]}`,
+		},
+		{
+			name: "Operator characters inside values",
+			args: args{
+				gcpFilter: `bindings.members=user:alice@example.com AND bindings.role:roles/owner bindings.members:user:*@gmail.com`,
+			},
+			want: `{"terms":[{"key":"bindings","attribute-key":"members","operator":"=","value":{"literal":"user:alice@example.com"},"logical-operator":"AND"},{"key":"bindings","attribute-key":"role","operator":":","value":{"literal":"^roles/owner$"}},{"key":"bindings","attribute-key":"members","operator":":","value":{"literal":"^user:.*@gmail\\.com$"}}]}`,
+		},
 		{
 			name: "Parse error",
 			args: args{
@@ -85,3 +129,155 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluate(t *testing.T) {
+	type args struct {
+		gcpFilter string
+		value     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Existence check of a set value",
+			args: args{
+				gcpFilter: `description:*`,
+				value:     "web",
+			},
+			want: true,
+		},
+		{
+			name: "Existence check of an unset value",
+			args: args{
+				gcpFilter: `description:*`,
+				value:     "",
+			},
+			want: false,
+		},
+		{
+			name: "Equality with the asterisk is not an existence check",
+			args: args{
+				gcpFilter: `description=*`,
+				value:     "web",
+			},
+			want: false,
+		},
+		{
+			name: "Unset value compared with a number",
+			args: args{
+				gcpFilter: `oauth2ClientId=123`,
+				value:     "",
+			},
+			want: false,
+		},
+		{
+			name: "Unset value is not lower than a number",
+			args: args{
+				gcpFilter: `mtu<1500`,
+				value:     "",
+			},
+			want: false,
+		},
+		{
+			name: "Unset value with a negative operator and a number",
+			args: args{
+				gcpFilter: `oauth2ClientId!=123`,
+				value:     "",
+			},
+			want: true,
+		},
+		{
+			name: "Non numeric value compared with a number",
+			args: args{
+				gcpFilter: `mtu<1500`,
+				value:     "auto",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parser.ParseString("", wrapValuesWithParentheses(quoteStringValues(tt.args.gcpFilter)))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			filter.compileExpression()
+			got, err := filter.Terms[0].evaluate(tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("evaluate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRepeatedWithoutItems(t *testing.T) {
+	tests := []struct {
+		gcpFilter string
+		want      bool
+	}{
+		{gcpFilter: `peerings:*`, want: false},
+		{gcpFilter: `peerings.name=default`, want: false},
+		{gcpFilter: `peerings.name!=default`, want: true},
+		{gcpFilter: `peerings.name!~^default$`, want: true},
+		{gcpFilter: `peerings.name:*`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gcpFilter, func(t *testing.T) {
+			filter, err := parser.ParseString("", wrapValuesWithParentheses(quoteStringValues(tt.gcpFilter)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := filterRepeated(filter.Terms[0], []*computepb.NetworkPeering{}, func(term, *computepb.NetworkPeering) (bool, error) {
+				t.Errorf("%v: item filtered without items", tt.gcpFilter)
+				return false, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("filterRepeated(): %v. want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		version      string
+		otherVersion string
+		want         int
+	}{
+		{version: "1.9", otherVersion: "1.27", want: -1},
+		{version: "1.28", otherVersion: "1.28.0", want: 0},
+		{version: "1.28", otherVersion: "1.28.1-gke.201", want: -1},
+		{version: "v1.27.3-gke.100", otherVersion: "1.27.3-gke.99", want: 1},
+		{version: "2.10.0-rc.1", otherVersion: "2.10.0", want: -1},
+		{version: "2.10.0+build.5", otherVersion: "2.10.0", want: 0},
+		{version: "1.0.0-alpha", otherVersion: "1.0.0-alpha.1", want: -1},
+		{version: "1.0.0-alpha.1", otherVersion: "1.0.0-alpha.beta", want: -1},
+		{version: "1.0.0-beta.2", otherVersion: "1.0.0-beta.11", want: -1},
+		{version: "1.0.0-rc.1", otherVersion: "1.0.0-beta.11", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.otherVersion, func(t *testing.T) {
+			version, ok := parseVersion(tt.version)
+			if !ok {
+				t.Fatalf("parseVersion(%v) failed", tt.version)
+			}
+			otherVersion, ok := parseVersion(tt.otherVersion)
+			if !ok {
+				t.Fatalf("parseVersion(%v) failed", tt.otherVersion)
+			}
+			if got := version.compare(otherVersion); got != tt.want {
+				t.Errorf("compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
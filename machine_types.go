@@ -0,0 +1,95 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpMachineType struct {
+	machineType *computepb.MachineType
+}
+
+func (g gcpMachineType) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "accelerators":
+		// e.g. accelerators.guestAcceleratorType:nvidia-tesla-*
+		return filterRepeated(t, g.machineType.GetAccelerators(), func(t term, accelerators *computepb.Accelerators) (bool, error) {
+			switch t.Key {
+			case "guestAcceleratorCount":
+				return t.evaluate(strconv.FormatInt(int64(accelerators.GetGuestAcceleratorCount()), 10))
+			case "guestAcceleratorType":
+				return t.evaluate(accelerators.GetGuestAcceleratorType())
+			default:
+				return false, fmt.Errorf("unknown accelerators key %v", t.Key)
+			}
+		})
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.machineType.GetCreationTimestamp())
+	case "deprecated":
+		return filterDeprecationStatus(t, g.machineType.GetDeprecated())
+	case "description":
+		return t.evaluate(g.machineType.GetDescription())
+	case "guestCpus":
+		return t.evaluate(strconv.FormatInt(int64(g.machineType.GetGuestCpus()), 10))
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.machineType.GetId(), 10))
+	case "imageSpaceGb":
+		return t.evaluate(strconv.FormatInt(int64(g.machineType.GetImageSpaceGb()), 10))
+	case "isSharedCpu":
+		return t.evaluate(strconv.FormatBool(g.machineType.GetIsSharedCpu()))
+	case "kind":
+		return t.evaluate(g.machineType.GetKind())
+	case "maximumPersistentDisks":
+		return t.evaluate(strconv.FormatInt(int64(g.machineType.GetMaximumPersistentDisks()), 10))
+	case "maximumPersistentDisksSizeGb":
+		return t.evaluate(strconv.FormatInt(g.machineType.GetMaximumPersistentDisksSizeGb(), 10))
+	case "memoryMb":
+		return t.evaluate(strconv.FormatInt(int64(g.machineType.GetMemoryMb()), 10))
+	case "name":
+		return t.evaluate(g.machineType.GetName())
+	case "scratchDisks":
+		const diskGbKey = "diskGb"
+		// Existence check e.g. scratchDisks:*
+		if t.AttributeKey == "" && t.existence() {
+			return len(g.machineType.GetScratchDisks()) > 0, nil
+		} else if diskGbKey == t.AttributeKey {
+			return filterRepeated(t, g.machineType.GetScratchDisks(), func(t term, scratchDisk *computepb.ScratchDisks) (bool, error) {
+				return t.evaluate(strconv.FormatInt(int64(scratchDisk.GetDiskGb()), 10))
+			})
+		}
+		return false, fmt.Errorf("unknown scratchDisks key %v", t.AttributeKey)
+	case "selfLink":
+		return t.evaluate(g.machineType.GetSelfLink())
+	case "zone":
+		return t.evaluate(g.machineType.GetZone())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterMachineTypes filters the given machine types according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/machineTypes/list
+func FilterMachineTypes(machineTypes []*computepb.MachineType, gcpFilter string) ([]*computepb.MachineType, error) {
+	return filterResources(machineTypes, gcpFilter, func(machineType *computepb.MachineType) gcpMachineType {
+		return gcpMachineType{machineType: machineType}
+	})
+}
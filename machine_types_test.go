@@ -0,0 +1,133 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type machineTypesArray []*computepb.MachineType
+
+func (m machineTypesArray) String() string {
+	if len(m) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, machineType := range m {
+		sb.WriteString(machineType.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterMachineTypes(t *testing.T) {
+	machineTypes := machineTypesArray{
+		{
+			Name:        toStringPtr("n2-highmem-16"),
+			Zone:        toStringPtr("europe-west1-b"),
+			GuestCpus:   toInt32Ptr(16),
+			MemoryMb:    toInt32Ptr(131072),
+			IsSharedCpu: toBoolPtr(false),
+		},
+		{
+			Name:        toStringPtr("e2-small"),
+			Zone:        toStringPtr("europe-west1-b"),
+			GuestCpus:   toInt32Ptr(2),
+			MemoryMb:    toInt32Ptr(2048),
+			IsSharedCpu: toBoolPtr(true),
+		},
+		{
+			Name:      toStringPtr("a2-highgpu-1g"),
+			Zone:      toStringPtr("us-central1-f"),
+			GuestCpus: toInt32Ptr(12),
+			MemoryMb:  toInt32Ptr(87040),
+			Accelerators: []*computepb.Accelerators{
+				{
+					GuestAcceleratorType:  toStringPtr("nvidia-tesla-a100"),
+					GuestAcceleratorCount: toInt32Ptr(1),
+				},
+			},
+			ScratchDisks: []*computepb.ScratchDisks{
+				{
+					DiskGb: toInt32Ptr(375),
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantMachineTypes machineTypesArray
+		wantErr          bool
+	}{
+		{
+			name: "CPUs, memory and zone",
+			args: args{
+				gcpFilter: `guestCpus>=16 memoryMb>65536 zone:europe-*`,
+			},
+			wantMachineTypes: machineTypesArray{
+				machineTypes[0],
+			},
+		},
+		{
+			name: "Accelerators and scratch disks",
+			args: args{
+				gcpFilter: `accelerators.guestAcceleratorType:nvidia-tesla-* AND accelerators.guestAcceleratorCount>=1 AND scratchDisks.diskGb=375`,
+			},
+			wantMachineTypes: machineTypesArray{
+				machineTypes[2],
+			},
+		},
+		{
+			name: "Shared CPUs and no accelerators",
+			args: args{
+				gcpFilter: `isSharedCpu=false AND NOT accelerators:* AND NOT scratchDisks:*`,
+			},
+			wantMachineTypes: machineTypesArray{
+				machineTypes[0],
+			},
+		},
+		{
+			name: "Unknown accelerators key",
+			args: args{
+				gcpFilter: `accelerators.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMachineTypes, err := FilterMachineTypes(machineTypes, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterMachineTypes() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotMachineTypesArray := machineTypesArray(gotMachineTypes)
+			if !reflect.DeepEqual(gotMachineTypesArray, tt.wantMachineTypes) {
+				t.Errorf("FilterMachineTypes(): \"%v\". want: \"%v\"", gotMachineTypesArray, tt.wantMachineTypes)
+			}
+			t.Log(gotMachineTypesArray)
+		})
+	}
+}
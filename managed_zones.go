@@ -0,0 +1,44 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+
+	"google.golang.org/api/dns/v1"
+)
+
+type gcpManagedZone struct {
+	managedZone *dns.ManagedZone
+}
+
+func (g gcpManagedZone) filterTerm(t term) (bool, error) {
+	if t.Key == "creationTime" {
+		return t.evaluateTimestampString(g.managedZone.CreationTime)
+	}
+	return filterField(t, reflect.ValueOf(g.managedZone))
+}
+
+// FilterManagedZones filters the given Cloud DNS managed zones according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/dns/managed-zones/list
+//  2. The keys are the ones of https://cloud.google.com/dns/docs/reference/rest/v1/managedZones#resource:-managedzone
+func FilterManagedZones(managedZones []*dns.ManagedZone, gcpFilter string) ([]*dns.ManagedZone, error) {
+	return filterResources(managedZones, gcpFilter, func(managedZone *dns.ManagedZone) gcpManagedZone {
+		return gcpManagedZone{managedZone: managedZone}
+	})
+}
@@ -0,0 +1,129 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/dns/v1"
+)
+
+type managedZonesArray []*dns.ManagedZone
+
+func (m managedZonesArray) String() string {
+	if len(m) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, managedZone := range m {
+		sb.WriteString(managedZone.Name + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterManagedZones(t *testing.T) {
+	managedZones := managedZonesArray{
+		{
+			Name:         "appgate-public",
+			DnsName:      "appgate.example.com.",
+			Visibility:   "public",
+			CreationTime: "2023-10-24T09:06:40.108Z",
+			DnssecConfig: &dns.ManagedZoneDnsSecConfig{
+				State:        "on",
+				NonExistence: "nsec3",
+			},
+			NameServers: []string{"ns-cloud-a1.googledomains.com.", "ns-cloud-a2.googledomains.com."},
+			Labels: map[string]string{
+				"team": "platform",
+			},
+		},
+		{
+			Name:         "appgate-internal",
+			DnsName:      "internal.appgate.example.com.",
+			Visibility:   "private",
+			CreationTime: "2024-02-01T12:00:00.000Z",
+			PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+				Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{
+					{
+						NetworkUrl: "https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default",
+					},
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantManagedZones managedZonesArray
+		wantErr          bool
+	}{
+		{
+			name: "Public zones with DNSSEC",
+			args: args{
+				gcpFilter: `visibility=public AND dnssecConfig.state=on AND nameServers:ns-cloud-a2.*`,
+			},
+			wantManagedZones: managedZonesArray{
+				managedZones[0],
+			},
+		},
+		{
+			name: "Private zones by network",
+			args: args{
+				gcpFilter: `privateVisibilityConfig.networks.networkUrl:*/networks/default AND NOT dnssecConfig:*`,
+			},
+			wantManagedZones: managedZonesArray{
+				managedZones[1],
+			},
+		},
+		{
+			name: "DNS name, creation time and labels",
+			args: args{
+				gcpFilter: `dnsName:*.example.com. AND creationTime<"2024-01-01T00:00:00Z" AND labels.team=platform`,
+			},
+			wantManagedZones: managedZonesArray{
+				managedZones[0],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotManagedZones, err := FilterManagedZones(managedZones, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterManagedZones() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotManagedZonesArray := managedZonesArray(gotManagedZones)
+			if !reflect.DeepEqual(gotManagedZonesArray, tt.wantManagedZones) {
+				t.Errorf("FilterManagedZones(): \"%v\". want: \"%v\"", gotManagedZonesArray, tt.wantManagedZones)
+			}
+			t.Log(gotManagedZonesArray)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpNetwork struct {
+	network *computepb.Network
+}
+
+func (g gcpNetwork) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "IPv4Range":
+		return t.evaluate(g.network.GetIPv4Range())
+	case "autoCreateSubnetworks":
+		return t.evaluate(strconv.FormatBool(g.network.GetAutoCreateSubnetworks()))
+	case "creationTimestamp":
+		return t.evaluate(g.network.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.network.GetDescription())
+	case "enableUlaInternalIpv6":
+		return t.evaluate(strconv.FormatBool(g.network.GetEnableUlaInternalIpv6()))
+	case "firewallPolicy":
+		return t.evaluate(g.network.GetFirewallPolicy())
+	case "gatewayIPv4":
+		return t.evaluate(g.network.GetGatewayIPv4())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.network.GetId(), 10))
+	case "internalIpv6Range":
+		return t.evaluate(g.network.GetInternalIpv6Range())
+	case "kind":
+		return t.evaluate(g.network.GetKind())
+	case "mtu":
+		return t.evaluate(strconv.FormatInt(int64(g.network.GetMtu()), 10))
+	case "name":
+		return t.evaluate(g.network.GetName())
+	case "networkFirewallPolicyEnforcementOrder":
+		return t.evaluate(g.network.GetNetworkFirewallPolicyEnforcementOrder())
+	case "peerings":
+		// e.g. peerings.network:*, peerings.state=ACTIVE
+		return filterRepeated(t, g.network.GetPeerings(), filterPeering)
+	case "routingConfig":
+		const routingModeKey = "routingMode"
+		if routingModeKey == t.AttributeKey {
+			return t.evaluate(g.network.GetRoutingConfig().GetRoutingMode())
+		}
+		return false, fmt.Errorf("unknown routingConfig key %v", t.AttributeKey)
+	case "selfLink":
+		return t.evaluate(g.network.GetSelfLink())
+	case "subnetworks":
+		return t.evaluateRepeated(g.network.GetSubnetworks())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterPeering(t term, peering *computepb.NetworkPeering) (bool, error) {
+	switch t.Key {
+	case "exportCustomRoutes":
+		return t.evaluate(strconv.FormatBool(peering.GetExportCustomRoutes()))
+	case "importCustomRoutes":
+		return t.evaluate(strconv.FormatBool(peering.GetImportCustomRoutes()))
+	case "name":
+		return t.evaluate(peering.GetName())
+	case "network":
+		return t.evaluate(peering.GetNetwork())
+	case "stackType":
+		return t.evaluate(peering.GetStackType())
+	case "state":
+		return t.evaluate(peering.GetState())
+	default:
+		return false, fmt.Errorf("unknown peerings key %v", t.Key)
+	}
+}
+
+// FilterNetworks filters the given networks according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/networks/list
+func FilterNetworks(networks []*computepb.Network, gcpFilter string) ([]*computepb.Network, error) {
+	return filterResources(networks, gcpFilter, func(network *computepb.Network) gcpNetwork {
+		return gcpNetwork{network: network}
+	})
+}
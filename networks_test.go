@@ -0,0 +1,155 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type networksArray []*computepb.Network
+
+func (n networksArray) String() string {
+	if len(n) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, network := range n {
+		sb.WriteString(network.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func toInt32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestFilterNetworks(t *testing.T) {
+	networks := networksArray{
+		{
+			AutoCreateSubnetworks: toBoolPtr(true),
+			CreationTimestamp:     toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Description:           toStringPtr("Default network for the project"),
+			Id:                    toUint64Ptr(6123462547295123412),
+			Kind:                  toStringPtr("compute#network"),
+			Mtu:                   toInt32Ptr(1460),
+			Name:                  toStringPtr("default"),
+			RoutingConfig: &computepb.NetworkRoutingConfig{
+				RoutingMode: toStringPtr("REGIONAL"),
+			},
+			SelfLink: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default"),
+			Subnetworks: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/subnetworks/default",
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-east1/subnetworks/default",
+			},
+		},
+		{
+			AutoCreateSubnetworks: toBoolPtr(false),
+			CreationTimestamp:     toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                    toUint64Ptr(8412365478123654789),
+			Kind:                  toStringPtr("compute#network"),
+			Mtu:                   toInt32Ptr(8896),
+			Name:                  toStringPtr("shared-vpc"),
+			Peerings: []*computepb.NetworkPeering{
+				{
+					Name:               toStringPtr("servicenetworking-googleapis-com"),
+					Network:            toStringPtr("https://www.googleapis.com/compute/v1/projects/a1b2c3-tp/global/networks/servicenetworking"),
+					State:              toStringPtr("ACTIVE"),
+					ExportCustomRoutes: toBoolPtr(false),
+				},
+			},
+			RoutingConfig: &computepb.NetworkRoutingConfig{
+				RoutingMode: toStringPtr("GLOBAL"),
+			},
+			SelfLink: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			Subnetworks: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/subnetworks/gke-nodes",
+			},
+		},
+		{
+			CreationTimestamp: toStringPtr("2021-03-11T01:12:21.102-08:00"),
+			GatewayIPv4:       toStringPtr("10.240.0.1"),
+			IPv4Range:         toStringPtr("10.240.0.0/16"),
+			Id:                toUint64Ptr(1234567812345678123),
+			Kind:              toStringPtr("compute#network"),
+			Name:              toStringPtr("legacy"),
+			SelfLink:          toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/legacy"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantNetworks networksArray
+		wantErr      bool
+	}{
+		{
+			name: "Auto mode networks",
+			args: args{
+				gcpFilter: `autoCreateSubnetworks=true`,
+			},
+			wantNetworks: networksArray{
+				networks[0],
+			},
+		},
+		{
+			name: "Peerings, routing mode, subnetworks",
+			args: args{
+				gcpFilter: `peerings.state=ACTIVE peerings.name:servicenetworking-* routingConfig.routingMode=GLOBAL subnetworks:*gke-nodes mtu>1500`,
+			},
+			wantNetworks: networksArray{
+				networks[1],
+			},
+		},
+		{
+			name: "Legacy networks",
+			args: args{
+				gcpFilter: `IPv4Range:* AND gatewayIPv4=10.240.0.1 -subnetworks:*`,
+			},
+			wantNetworks: networksArray{
+				networks[2],
+			},
+		},
+		{
+			name: "Unknown peerings key",
+			args: args{
+				gcpFilter: `peerings.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNetworks, err := FilterNetworks(networks, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterNetworks() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotNetworksArray := networksArray(gotNetworks)
+			if !reflect.DeepEqual(gotNetworksArray, tt.wantNetworks) {
+				t.Errorf("FilterNetworks(): \"%v\". want: \"%v\"", gotNetworksArray, tt.wantNetworks)
+			}
+			t.Log(gotNetworksArray)
+		})
+	}
+}
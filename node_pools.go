@@ -0,0 +1,143 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+type gcpNodePool struct {
+	nodePool *containerpb.NodePool
+}
+
+func (g gcpNodePool) filterTerm(t term) (bool, error) {
+	return filterNodePool(t, g.nodePool)
+}
+
+func filterNodePool(t term, nodePool *containerpb.NodePool) (bool, error) {
+	switch t.Key {
+	case "autoscaling":
+		autoscaling := nodePool.GetAutoscaling()
+		switch t.AttributeKey {
+		case "autoprovisioned":
+			return t.evaluate(strconv.FormatBool(autoscaling.GetAutoprovisioned()))
+		case "enabled":
+			return t.evaluate(strconv.FormatBool(autoscaling.GetEnabled()))
+		case "locationPolicy":
+			return t.evaluate(autoscaling.GetLocationPolicy().String())
+		case "maxNodeCount":
+			return t.evaluate(strconv.FormatInt(int64(autoscaling.GetMaxNodeCount()), 10))
+		case "minNodeCount":
+			return t.evaluate(strconv.FormatInt(int64(autoscaling.GetMinNodeCount()), 10))
+		case "totalMaxNodeCount":
+			return t.evaluate(strconv.FormatInt(int64(autoscaling.GetTotalMaxNodeCount()), 10))
+		case "totalMinNodeCount":
+			return t.evaluate(strconv.FormatInt(int64(autoscaling.GetTotalMinNodeCount()), 10))
+		default:
+			return false, fmt.Errorf("unknown autoscaling key %v", t.AttributeKey)
+		}
+	case "config":
+		// e.g. config.machineType:e2-*, config.labels.env=prod
+		return filterNodeConfig(t.descend(), nodePool.GetConfig())
+	case "etag":
+		return t.evaluate(nodePool.GetEtag())
+	case "initialNodeCount":
+		return t.evaluate(strconv.FormatInt(int64(nodePool.GetInitialNodeCount()), 10))
+	case "instanceGroupUrls":
+		return t.evaluateRepeated(nodePool.GetInstanceGroupUrls())
+	case "locations":
+		return t.evaluateRepeated(nodePool.GetLocations())
+	case "management":
+		management := nodePool.GetManagement()
+		switch t.AttributeKey {
+		case "autoRepair":
+			return t.evaluate(strconv.FormatBool(management.GetAutoRepair()))
+		case "autoUpgrade":
+			return t.evaluate(strconv.FormatBool(management.GetAutoUpgrade()))
+		default:
+			return false, fmt.Errorf("unknown management key %v", t.AttributeKey)
+		}
+	case "maxPodsConstraint":
+		const maxPodsPerNodeKey = "maxPodsPerNode"
+		if maxPodsPerNodeKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatInt(nodePool.GetMaxPodsConstraint().GetMaxPodsPerNode(), 10))
+		}
+		return false, fmt.Errorf("unknown maxPodsConstraint key %v", t.AttributeKey)
+	case "name":
+		return t.evaluate(nodePool.GetName())
+	case "podIpv4CidrSize":
+		return t.evaluate(strconv.FormatInt(int64(nodePool.GetPodIpv4CidrSize()), 10))
+	case "selfLink":
+		return t.evaluate(nodePool.GetSelfLink())
+	case "status":
+		return t.evaluate(nodePool.GetStatus().String())
+	case "statusMessage":
+		return t.evaluate(nodePool.GetStatusMessage())
+	case "version":
+		return t.evaluateVersion(nodePool.GetVersion())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterNodeConfig(t term, config *containerpb.NodeConfig) (bool, error) {
+	switch t.Key {
+	case "bootDiskKmsKey":
+		return t.evaluate(config.GetBootDiskKmsKey())
+	case "diskSizeGb":
+		return t.evaluate(strconv.FormatInt(int64(config.GetDiskSizeGb()), 10))
+	case "diskType":
+		return t.evaluate(config.GetDiskType())
+	case "imageType":
+		return t.evaluate(config.GetImageType())
+	case "labels":
+		return t.evaluateLabels(config.GetLabels())
+	case "localSsdCount":
+		return t.evaluate(strconv.FormatInt(int64(config.GetLocalSsdCount()), 10))
+	case "machineType":
+		return t.evaluate(config.GetMachineType())
+	case "minCpuPlatform":
+		return t.evaluate(config.GetMinCpuPlatform())
+	case "oauthScopes":
+		return t.evaluateRepeated(config.GetOauthScopes())
+	case "preemptible":
+		return t.evaluate(strconv.FormatBool(config.GetPreemptible()))
+	case "resourceLabels":
+		return t.evaluateLabels(config.GetResourceLabels())
+	case "serviceAccount":
+		return t.evaluate(config.GetServiceAccount())
+	case "spot":
+		return t.evaluate(strconv.FormatBool(config.GetSpot()))
+	case "tags":
+		return t.evaluateRepeated(config.GetTags())
+	default:
+		return false, fmt.Errorf("unknown config key %v", t.Key)
+	}
+}
+
+// FilterNodePools filters the given GKE node pools according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/container/node-pools/list
+//  2. version is compared as a version e.g. version<1.28 is true for 1.27.3-gke.100
+func FilterNodePools(nodePools []*containerpb.NodePool, gcpFilter string) ([]*containerpb.NodePool, error) {
+	return filterResources(nodePools, gcpFilter, func(nodePool *containerpb.NodePool) gcpNodePool {
+		return gcpNodePool{nodePool: nodePool}
+	})
+}
@@ -0,0 +1,179 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+type nodePoolsArray []*containerpb.NodePool
+
+func (n nodePoolsArray) String() string {
+	if len(n) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, nodePool := range n {
+		sb.WriteString(nodePool.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterNodePools(t *testing.T) {
+	nodePools := nodePoolsArray{
+		{
+			Name:    "default-pool",
+			Version: "1.27.3-gke.100",
+			Status:  containerpb.NodePool_RUNNING,
+			Config: &containerpb.NodeConfig{
+				MachineType: "n2-standard-4",
+				DiskSizeGb:  100,
+				Labels: map[string]string{
+					"team": "platform",
+				},
+				OauthScopes: []string{
+					"https://www.googleapis.com/auth/cloud-platform",
+				},
+			},
+			Management: &containerpb.NodeManagement{
+				AutoUpgrade: true,
+				AutoRepair:  true,
+			},
+			Locations: []string{"europe-west1-b", "europe-west1-c"},
+		},
+		{
+			Name:    "spot-pool",
+			Version: "1.10.2-gke.1",
+			Status:  containerpb.NodePool_RUNNING_WITH_ERROR,
+			Config: &containerpb.NodeConfig{
+				MachineType: "e2-medium",
+				DiskSizeGb:  50,
+				Spot:        true,
+				Tags:        []string{"batch"},
+			},
+			Locations: []string{"europe-west1-d"},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantNodePools nodePoolsArray
+		wantErr       bool
+	}{
+		{
+			name: "Version comparison",
+			args: args{
+				gcpFilter: `version>1.9`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[0],
+				nodePools[1],
+			},
+		},
+		{
+			name: "Version comparison with two digit minor",
+			args: args{
+				gcpFilter: `version<1.27`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[1],
+			},
+		},
+		{
+			name: "Pre-release versions",
+			args: args{
+				gcpFilter: `version<1.27.3 AND version>1.27.3-gke.99`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[0],
+			},
+		},
+		{
+			name: "Exact version equality",
+			args: args{
+				gcpFilter: `version=1.10 OR version!=1.10.2-gke.1`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[0],
+			},
+		},
+		{
+			name: "Labels are not versions",
+			args: args{
+				gcpFilter: `config.labels.team>=2.10`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Config",
+			args: args{
+				gcpFilter: `config.spot=true AND config.diskSizeGb<100 AND config.tags:batch`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[1],
+			},
+		},
+		{
+			name: "Config labels and scopes, management",
+			args: args{
+				gcpFilter: `config.labels.team=platform AND config.oauthScopes:*cloud-platform AND management.autoUpgrade=true`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[0],
+			},
+		},
+		{
+			name: "Status and locations",
+			args: args{
+				gcpFilter: `status=RUNNING_WITH_ERROR OR locations:europe-west1-c`,
+			},
+			wantNodePools: nodePoolsArray{
+				nodePools[0],
+				nodePools[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNodePools, err := FilterNodePools(nodePools, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterNodePools() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotNodePoolsArray := nodePoolsArray(gotNodePools)
+			if !reflect.DeepEqual(gotNodePoolsArray, tt.wantNodePools) {
+				t.Errorf("FilterNodePools(): \"%v\". want: \"%v\"", gotNodePoolsArray, tt.wantNodePools)
+			}
+			t.Log(gotNodePoolsArray)
+		})
+	}
+}
@@ -0,0 +1,359 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type gcpObject struct {
+	object reflect.Value
+	// The formats of the keys declared with the ObjectsFilterOptions keyed by the lowercase keys
+	formats map[string]fieldFormat
+}
+
+// fieldFormat is the format of the values of a key e.g. times in milliseconds since the epoch
+type fieldFormat int
+
+const (
+	// plainFormat values are evaluated according to their type
+	plainFormat fieldFormat = iota
+	// epochMillisecondsFormat values are times in milliseconds since the epoch e.g. creationTime
+	epochMillisecondsFormat
+	// versionFormat values are versions e.g. engineVersion
+	versionFormat
+)
+
+// ObjectsFilterOption configures the optional schema that FilterObjects can use
+type ObjectsFilterOption func(*gcpObject)
+
+// WithEpochMilliseconds declares the keys e.g. creationTime, streamingBuffer.oldestEntryTime whose numbers are
+// times in milliseconds since the epoch. They are compared with RFC3339 times e.g. creationTime<"2024-01-01T00:00:00Z"
+func WithEpochMilliseconds(keys ...string) ObjectsFilterOption {
+	return func(g *gcpObject) {
+		g.setFormat(epochMillisecondsFormat, keys)
+	}
+}
+
+// WithVersions declares the keys e.g. engineVersion, spec.kubernetesVersion whose strings are versions. They
+// are compared by the precedence of https://semver.org e.g. engineVersion<1.28 is true for 1.9.2
+func WithVersions(keys ...string) ObjectsFilterOption {
+	return func(g *gcpObject) {
+		g.setFormat(versionFormat, keys)
+	}
+}
+
+func (g gcpObject) filterTerm(t term) (bool, error) {
+	key := t.Key
+	if t.AttributeKey != "" {
+		key += "." + t.AttributeKey
+	}
+	// The keys are matched case insensitively as the fields of the structs
+	return filterFormattedField(t, g.object, g.formats[strings.ToLower(key)])
+}
+
+func (g *gcpObject) setFormat(format fieldFormat, keys []string) {
+	if g.formats == nil {
+		g.formats = make(map[string]fieldFormat, len(keys))
+	}
+	for _, key := range keys {
+		g.formats[strings.ToLower(key)] = format
+	}
+}
+
+// filterField filters the field t.Key of the given struct or map. It is the fallback of the resources of the
+// REST clients e.g. sqladmin.DatabaseInstance, dns.ManagedZone, whose keys are the json tags of their fields
+// e.g. settings.tier, dnssecConfig.state therefore they need special cases only for the keys that are not
+// plain values e.g. RFC3339 string times
+func filterField(t term, object reflect.Value) (bool, error) {
+	return filterFormattedField(t, object, plainFormat)
+}
+
+// filterFormattedField filters the field t.Key of the given struct or map whose values have the given format
+func filterFormattedField(t term, object reflect.Value, format fieldFormat) (bool, error) {
+	object = indirect(protoValue(object))
+	var field reflect.Value
+	switch object.Kind() {
+	case reflect.Struct:
+		var ok bool
+		if field, ok = structField(object, t.Key); !ok {
+			return false, fmt.Errorf("unknown key %v", t.Key)
+		}
+	case reflect.Map:
+		if object.Type().Key().Kind() != reflect.String {
+			return false, fmt.Errorf("unsupported map key type %v of key %v", object.Type().Key(), t.Key)
+		}
+		// Keys missing from maps are unset
+		field = object.MapIndex(reflect.ValueOf(t.Key).Convert(object.Type().Key()))
+	case reflect.Invalid:
+		// Unset parent e.g. nil pointer or missing key
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+	return filterFormattedFieldValue(t, field, format)
+}
+
+// filterFieldValue filters the value of the field t.Key descending to t.AttributeKey if any
+func filterFieldValue(t term, field reflect.Value) (bool, error) {
+	return filterFormattedFieldValue(t, field, plainFormat)
+}
+
+// filterFormattedFieldValue filters the value of the field t.Key, whose values have the given format,
+// descending to t.AttributeKey if any
+func filterFormattedFieldValue(t term, field reflect.Value, format fieldFormat) (bool, error) {
+	field = indirect(protoValue(field))
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 || field.Kind() == reflect.Array {
+		// e.g. tags:web, disks.boot=true
+		items := make([]reflect.Value, field.Len())
+		for i := range items {
+			items[i] = field.Index(i)
+		}
+		return filterRepeated(t, items, func(t term, item reflect.Value) (bool, error) {
+			if t.Key == "" {
+				return filterFormattedFieldValue(t, item, format)
+			}
+			return filterFormattedField(t, item, format)
+		})
+	}
+	if t.AttributeKey != "" {
+		return filterFormattedField(t.descend(), field, format)
+	}
+	return t.evaluateField(field, format)
+}
+
+// evaluateField evaluates a scalar field. Structs and maps support only the existence check
+func (t term) evaluateField(field reflect.Value, format fieldFormat) (bool, error) {
+	if !field.IsValid() {
+		return t.evaluateUnset()
+	}
+	if format == epochMillisecondsFormat {
+		if milliseconds, ok := epochMilliseconds(field); ok {
+			return t.evaluateEpochMilliseconds(milliseconds, true)
+		}
+	}
+	if format == versionFormat && field.Kind() == reflect.String {
+		return t.evaluateVersion(field.String())
+	}
+	if field.CanInterface() {
+		switch fieldValue := field.Interface().(type) {
+		case Version:
+			return t.evaluateVersion(string(fieldValue))
+		case time.Time:
+			return t.evaluateTime(fieldValue)
+		case time.Duration:
+			return t.evaluateDuration(fieldValue)
+		case fmt.Stringer:
+			// e.g. enums
+			if field.Kind() != reflect.Struct {
+				return t.evaluateString(fieldValue.String())
+			}
+		}
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return t.evaluateString(field.String())
+	case reflect.Bool:
+		return t.evaluateString(strconv.FormatBool(field.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return t.evaluate(strconv.FormatInt(field.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return t.evaluate(strconv.FormatUint(field.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return t.evaluate(strconv.FormatFloat(field.Float(), 'f', -1, 64))
+	case reflect.Slice:
+		// []byte
+		return t.evaluate(string(field.Bytes()))
+	case reflect.Struct, reflect.Map:
+		// Existence check e.g. labels:*
+		if t.existence() {
+			return !field.IsZero() && !(field.Kind() == reflect.Map && field.Len() == 0), nil
+		}
+	}
+	return false, fmt.Errorf("key %v of type %v cannot be compared", t.Key, field.Type())
+}
+
+// forceSent reports whether the integer field of a struct of the REST clients e.g. bigquery.Table is set. Their
+// fields are not pointers therefore zero is set only if the field is in the ForceSendFields of the struct
+func forceSent[T int64 | uint64](value T, field string, forceSendFields []string) bool {
+	return value != 0 || slices.Contains(forceSendFields, field)
+}
+
+// epochMilliseconds returns the milliseconds of the integer, float e.g. decoded from JSON or numeric string
+// e.g. int64 encoded as JSON string fields
+func epochMilliseconds(field reflect.Value) (int64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int64(field.Float()), true
+	case reflect.String:
+		milliseconds, err := strconv.ParseInt(field.String(), 10, 64)
+		return milliseconds, err == nil
+	}
+	return 0, false
+}
+
+// evaluateString evaluates strings of fields with any type. Unlike evaluate, numeric filter values
+// are compared textually with strings that are not numbers instead of failing e.g. status=1 is false
+// for RUNNING, enabled=1 is false for true
+func (t term) evaluateString(s string) (bool, error) {
+	if _, err := strconv.ParseFloat(s, 64); err == nil || s == "" || t.existence() {
+		return t.evaluate(s)
+	}
+	filterValues := t.filterValues()
+	literalValues := make([]value, len(filterValues))
+	for i, filterValue := range filterValues {
+		if filterValue.Number != nil {
+			literal := filterValue.text
+			if t.Operator == ":" {
+				literal = wildcardToRegexp(literal)
+			}
+			filterValue = value{Literal: &literal, text: filterValue.text}
+		}
+		literalValues[i] = filterValue
+	}
+	t.Value = nil
+	t.ValuesList = &list{Values: literalValues}
+	return t.evaluate(s)
+}
+
+// evaluateUnset evaluates an unset field e.g. nil pointer, key missing from a map or JSON null. It
+// matches the negative operators e.g. labels.env!=prod is true for resources without the label and
+// the null literal e.g. resource.data.description=null
+func (t term) evaluateUnset() (bool, error) {
+	if t.existence() {
+		return false, nil
+	}
+	_, negative := negativeOperators[t.Operator]
+	for _, filterValue := range t.filterValues() {
+		if strings.EqualFold(filterValue.text, "null") {
+			return !negative, nil
+		}
+	}
+	return negative, nil
+}
+
+// protoValue converts the protobuf types of semi-structured data i.e. Struct, ListValue and Value to
+// their Go counterparts i.e. map[string]any, []any and float64, string, bool or nil for null
+func protoValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+	switch protoValue := v.Interface().(type) {
+	case *structpb.Struct:
+		if protoValue == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(protoValue.AsMap())
+	case *structpb.ListValue:
+		if protoValue == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(protoValue.AsSlice())
+	case *structpb.Value:
+		if protoValue == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(protoValue.AsInterface())
+	case *durationpb.Duration:
+		if protoValue == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(protoValue.AsDuration())
+	case *timestamppb.Timestamp:
+		if protoValue == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(protoValue.AsTime())
+	}
+	return v
+}
+
+// indirect dereferences pointers and interfaces. The result is invalid for nil ones
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// structField returns the exported field whose json tag or name under case folding is the given
+// key. Fields of embedded structs are promoted
+func structField(object reflect.Value, key string) (reflect.Value, bool) {
+	objectType := object.Type()
+	var embedded []int
+	for i := 0; i < objectType.NumField(); i++ {
+		structField := objectType.Field(i)
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if structField.Anonymous && name == "" {
+			embedded = append(embedded, i)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if name == key || strings.EqualFold(structField.Name, key) {
+			return object.Field(i), true
+		}
+	}
+	for _, i := range embedded {
+		if field := indirect(object.Field(i)); field.Kind() == reflect.Struct {
+			if field, ok := structField(field, key); ok {
+				return field, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// FilterObjects filters the given Go values according to the gcpFilter
+// Notes:
+//  1. The values can be structs, maps with string keys e.g. map[string]any decoded from JSON or YAML, and pointers to them
+//  2. A key is the json tag of a struct field or its name case insensitively. Keys missing from maps are unset
+//  3. Slices are repeated fields e.g. tags:web, disks.boot=true
+//  4. The protobuf Struct, ListValue and Value are traversed as maps, slices and their JSON values. JSON null is
+//     unset and matches the null literal e.g. description=null
+//  5. The keys of times in milliseconds since the epoch can be declared with WithEpochMilliseconds and the ones
+//     of versions with WithVersions. Fields of type Version are versions too
+func FilterObjects[T any](objects []T, gcpFilter string, opts ...ObjectsFilterOption) ([]T, error) {
+	return filterResources(objects, gcpFilter, func(object T) gcpObject {
+		gcpResource := gcpObject{
+			object: reflect.ValueOf(object),
+		}
+		for _, opt := range opts {
+			opt(&gcpResource)
+		}
+		return gcpResource
+	})
+}
@@ -0,0 +1,261 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type objectsArray []any
+
+func (o objectsArray) String() string {
+	if len(o) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, object := range o {
+		switch object := object.(type) {
+		case map[string]any:
+			sb.WriteString(fmt.Sprint(object["name"]) + " ")
+		case *terraformResource:
+			sb.WriteString(object.Name + " ")
+		case *structpb.Struct:
+			sb.WriteString(object.GetFields()["name"].GetStringValue() + " ")
+		}
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+type terraformMeta struct {
+	Provider string `json:"provider"`
+}
+
+type terraformResource struct {
+	terraformMeta
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Count     int               `json:"count"`
+	Tainted   bool              `json:"tainted,omitempty"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	CreatedAt time.Time         `json:"created_at"`
+	Timeout   time.Duration     `json:"timeout"`
+	Instances []struct {
+		IndexKey   int            `json:"index_key"`
+		Attributes map[string]any `json:"attributes"`
+	} `json:"instances"`
+	internal string
+}
+
+func TestFilterObjects(t *testing.T) {
+	var documents []map[string]any
+	// The numbers are decoded as json.Number to keep the precision of the integers e.g. id
+	decoder := json.NewDecoder(strings.NewReader(`[
+		{"name": "db-1", "creationTime": "1698138400108", "kind": "postgres", "version": "14.2", "replicas": 3, "owner": {"team": "data", "oncall": true}, "tags": ["prod", "eu"]},
+		{"name": "cache-1", "kind": "redis", "version": "7.0.12", "replicas": 1, "owner": {"team": "platform"}, "tags": []},
+		{"name": "db-2", "creationTime": 1709294400000, "id": 1360066178417571791, "kind": "postgres", "version": "16.1", "owner": null, "tags": ["dev"]}
+	]`))
+	decoder.UseNumber()
+	if err := decoder.Decode(&documents); err != nil {
+		t.Fatal(err)
+	}
+	resources := []*terraformResource{
+		{
+			terraformMeta: terraformMeta{Provider: "registry.terraform.io/hashicorp/google"},
+			Name:          "bastion",
+			Type:          "google_compute_instance",
+			Count:         2,
+			Tags:          []string{"ssh"},
+			Labels:        map[string]string{"env": "prod"},
+			CreatedAt:     time.Date(2023, 10, 24, 9, 6, 40, 0, time.UTC),
+			Timeout:       90 * time.Minute,
+		},
+		{
+			terraformMeta: terraformMeta{Provider: "registry.terraform.io/hashicorp/aws"},
+			Name:          "logs",
+			Type:          "aws_s3_bucket",
+			Count:         1,
+			Tainted:       true,
+		},
+	}
+	resources[0].Instances = append(resources[0].Instances, struct {
+		IndexKey   int            `json:"index_key"`
+		Attributes map[string]any `json:"attributes"`
+	}{
+		IndexKey:   0,
+		Attributes: map[string]any{"machine_type": "e2-small"},
+	})
+
+	var structs []*structpb.Struct
+	for _, fields := range []map[string]any{
+		{"name": "run-api", "status": "RUNNING", "replicas": 3, "public": true, "description": nil, "config": map[string]any{"port": 8080, "env": []any{"PROD", "EU"}}},
+		{"name": "run-worker", "status": "1", "replicas": 0, "public": false, "description": "Queue worker", "config": map[string]any{"port": "9090"}},
+	} {
+		data, err := structpb.NewStruct(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		structs = append(structs, data)
+	}
+
+	objects := objectsArray{documents[0], documents[1], documents[2], resources[0], resources[1], structs[0], structs[1]}
+	type args struct {
+		gcpFilter string
+		opts      []ObjectsFilterOption
+	}
+	tests := []struct {
+		name        string
+		args        args
+		objects     objectsArray
+		wantObjects objectsArray
+		wantErr     bool
+	}{
+		{
+			name:        "JSON documents",
+			args:        args{gcpFilter: `kind=postgres AND owner.team:*`},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[0]},
+		},
+		{
+			name:        "JSON numbers, booleans and version patterns",
+			args:        args{gcpFilter: `replicas>=1 AND NOT owner.oncall=true AND version:7.0.*`},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[1]},
+		},
+		{
+			name:        "JSON arrays",
+			args:        args{gcpFilter: `tags:(prod dev) OR NOT tags:*`},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[0], objects[1], objects[2]},
+		},
+		{
+			name:        "Missing keys are unset",
+			args:        args{gcpFilter: `owner.team!=data AND replicas:*`},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[1]},
+		},
+		{
+			name: "Epoch milliseconds",
+			args: args{
+				gcpFilter: `creationTime<"2024-01-01T00:00:00Z" OR creationTime>="2024-03-01T12:00:00Z"`,
+				opts:      []ObjectsFilterOption{WithEpochMilliseconds("creationTime")},
+			},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[0], objects[2]},
+		},
+		{
+			name: "Epoch milliseconds as numbers",
+			args: args{
+				gcpFilter: `creationTime>1700000000000 AND id=1360066178417571791`,
+				opts:      []ObjectsFilterOption{WithEpochMilliseconds("creationTime")},
+			},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[2]},
+		},
+		{
+			name: "Versions",
+			args: args{
+				gcpFilter: `version>=8 AND version<16.1 AND version!=(7.0.12 16.1)`,
+				opts:      []ObjectsFilterOption{WithVersions("version")},
+			},
+			objects:     objects[:3],
+			wantObjects: objectsArray{objects[0]},
+		},
+		{
+			name:        "Struct json tags, field names and embedded structs",
+			args:        args{gcpFilter: `type:google_* AND Count=2 AND provider:*hashicorp/google`},
+			objects:     objects[3:],
+			wantObjects: objectsArray{objects[3]},
+		},
+		{
+			name:        "Struct slices, maps and times",
+			args:        args{gcpFilter: `instances.attributes.machine_type=e2-small AND labels.env=prod AND created_at<"2024-01-01T00:00:00Z"`},
+			objects:     objects[3:],
+			wantObjects: objectsArray{objects[3]},
+		},
+		{
+			name:        "Struct booleans",
+			args:        args{gcpFilter: `tainted=true`},
+			objects:     objects[3:],
+			wantObjects: objectsArray{objects[4]},
+		},
+		{
+			name:        "Struct durations",
+			args:        args{gcpFilter: `timeout>=1h30m AND timeout=1.5h AND timeout<PT2H`},
+			objects:     objects[3:],
+			wantObjects: objectsArray{objects[3]},
+		},
+		{
+			name:        "Struct zero durations",
+			args:        args{gcpFilter: `timeout<1d AND timeout<30s`},
+			objects:     objects[3:],
+			wantObjects: objectsArray{objects[4]},
+		},
+		{
+			name:        "Protobuf Struct numbers and booleans",
+			args:        args{gcpFilter: `replicas>=1 AND public=true AND config.port<9000`},
+			objects:     objects[5:],
+			wantObjects: objectsArray{objects[5]},
+		},
+		{
+			name:        "Protobuf Struct strings compared with numbers",
+			args:        args{gcpFilter: `status=1 OR config.port=9090`},
+			objects:     objects[5:],
+			wantObjects: objectsArray{objects[6]},
+		},
+		{
+			name:        "Protobuf Struct null and lists",
+			args:        args{gcpFilter: `description=null AND config.env:eu`},
+			objects:     objects[5:],
+			wantObjects: objectsArray{objects[5]},
+		},
+		{
+			name:        "Protobuf Struct not null",
+			args:        args{gcpFilter: `description!=null AND NOT config.env:*`},
+			objects:     objects[5:],
+			wantObjects: objectsArray{objects[6]},
+		},
+		{
+			name:    "Unexported field",
+			args:    args{gcpFilter: `internal:*`},
+			objects: objects[3:],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotObjects, err := FilterObjects(tt.objects, tt.args.gcpFilter, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterObjects() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotObjectsArray := objectsArray(gotObjects)
+			if !reflect.DeepEqual(gotObjectsArray, tt.wantObjects) {
+				t.Errorf("FilterObjects(): \"%v\". want: \"%v\"", gotObjectsArray, tt.wantObjects)
+			}
+			t.Log(gotObjectsArray)
+		})
+	}
+}
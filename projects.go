@@ -21,11 +21,93 @@ import (
 	"strings"
 	"time"
 
+	"cloud.google.com/go/iam/apiv1/iampb"
 	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 )
 
 type gcpProject struct {
-	project *resourcemanagerpb.Project
+	project     *resourcemanagerpb.Project
+	hierarchy   *Hierarchy
+	iamPolicies map[string]*iampb.Policy
+}
+
+// ProjectsFilterOption configures the optional data that FilterProjects can use
+type ProjectsFilterOption func(*gcpProject)
+
+// WithHierarchy enables the ancestors key using the given resource hierarchy
+// e.g. ancestors:folders/123, ancestors.displayName:Engineering, ancestors.depth<=2
+func WithHierarchy(hierarchy *Hierarchy) ProjectsFilterOption {
+	return func(g *gcpProject) {
+		g.hierarchy = hierarchy
+	}
+}
+
+// WithIAMPolicies enables the iamPolicy key using the given IAM policies of the projects keyed by
+// the projects' resource names e.g. projects/82699087620 or their ids e.g. appgate-dev
+// e.g. iamPolicy.bindings.role:roles/owner iamPolicy.bindings.members:user:*@gmail.com
+func WithIAMPolicies(policies map[string]*iampb.Policy) ProjectsFilterOption {
+	return func(g *gcpProject) {
+		g.iamPolicies = policies
+	}
+}
+
+func (g gcpProject) filterIAMPolicy(t term) (bool, error) {
+	if g.iamPolicies == nil {
+		return false, fmt.Errorf("key %v requires IAM policies", t.Key)
+	}
+	policy, ok := g.iamPolicies[g.project.GetName()]
+	if !ok {
+		policy = g.iamPolicies[g.project.GetProjectId()]
+	}
+	// Existence check e.g. iamPolicy:*
+	if t.AttributeKey == "" && t.existence() {
+		return policy != nil, nil
+	}
+	// Projects without a policy have an empty one
+	return filterIAMPolicy(t.descend(), policy)
+}
+
+func (g gcpProject) filterAncestors(t term) (bool, error) {
+	if g.hierarchy == nil {
+		return false, fmt.Errorf("key %v requires a hierarchy", t.Key)
+	}
+	ancestors, err := g.hierarchy.ancestors(g.project.GetParent())
+	if err != nil {
+		return false, err
+	}
+	attributeKey := strings.ToLower(t.AttributeKey)
+	switch attributeKey {
+	// e.g. ancestors:folders/123
+	case "":
+		return t.evaluateRepeated(ancestors)
+	// e.g. ancestors.type:folders
+	case "type", "id":
+		items := make([]string, 0, len(ancestors))
+		for _, ancestor := range ancestors {
+			ancestorParts := strings.Split(ancestor, "/")
+			if len(ancestorParts) < 2 {
+				return false, fmt.Errorf("invalid ancestor %v", ancestor)
+			}
+			if attributeKey == "type" {
+				items = append(items, ancestorParts[0])
+			} else {
+				items = append(items, ancestorParts[1])
+			}
+		}
+		return t.evaluateRepeated(items)
+	// e.g. ancestors.displayName:Engineering
+	case "displayname", "name":
+		items := make([]string, 0, len(ancestors))
+		for _, ancestor := range ancestors {
+			items = append(items, g.hierarchy.displayName(ancestor))
+		}
+		return t.evaluateRepeated(items)
+	// e.g. ancestors.depth<=2
+	case "depth":
+		return t.evaluate(fmt.Sprint(len(ancestors)))
+	default:
+		return false, fmt.Errorf("unknown attribute key %v", t.AttributeKey)
+	}
 }
 
 func (g gcpProject) filterTerm(t term) (bool, error) {
@@ -83,6 +165,10 @@ func (g gcpProject) filterTerm(t term) (bool, error) {
 		return t.evaluateTimestamp(g.project.GetDeleteTime().AsTime().Format(time.RFC3339))
 	case "etag":
 		return t.evaluate(g.project.GetEtag())
+	case "ancestors":
+		return g.filterAncestors(t)
+	case "iampolicy":
+		return g.filterIAMPolicy(t)
 	case "labels":
 		// e.g. labels.color:red, labels.color:*, -labels.color:red
 		for labelKey, labelValue := range g.project.GetLabels() {
@@ -103,22 +189,16 @@ func (g gcpProject) filterTerm(t term) (bool, error) {
 // FilterProjects filters the given projects according to the gcpFilter
 // Notes:
 //  1. The query shall comply with https://cloud.google.com/resource-manager/reference/rest/v3/projects/search
-func FilterProjects(projects []*resourcemanagerpb.Project, gcpFilter string) ([]*resourcemanagerpb.Project, error) {
-	filteredProjects := make([]*resourcemanagerpb.Project, 0, len(projects))
-	for _, project := range projects {
-		resource := resource[gcpProject]{
-			gcpResource: gcpProject{
-				project: project,
-			},
-			gcpFilter: gcpFilter,
-		}
-		keepProject, err := resource.filter()
-		if err != nil {
-			return nil, err
+//  2. The ancestors key is available only when a hierarchy is given with WithHierarchy()
+//  3. The iamPolicy key is available only when IAM policies are given with WithIAMPolicies()
+func FilterProjects(projects []*resourcemanagerpb.Project, gcpFilter string, opts ...ProjectsFilterOption) ([]*resourcemanagerpb.Project, error) {
+	return filterResources(projects, gcpFilter, func(project *resourcemanagerpb.Project) gcpProject {
+		gcpResource := gcpProject{
+			project: project,
 		}
-		if keepProject {
-			filteredProjects = append(filteredProjects, project)
+		for _, opt := range opts {
+			opt(&gcpResource)
 		}
-	}
-	return filteredProjects, nil
+		return gcpResource
+	})
 }
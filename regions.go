@@ -0,0 +1,167 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpRegion struct {
+	region *computepb.Region
+	// The quota that all the quotas terms are evaluated against. It is nil for regions without quotas
+	quota *computepb.Quota
+}
+
+func (g gcpRegion) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.region.GetCreationTimestamp())
+	case "deprecated":
+		return filterDeprecationStatus(t, g.region.GetDeprecated())
+	case "description":
+		return t.evaluate(g.region.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.region.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.region.GetKind())
+	case "name":
+		return t.evaluate(g.region.GetName())
+	case "quotas":
+		// Existence check e.g. quotas:*
+		if t.AttributeKey == "" && t.existence() {
+			return len(g.region.GetQuotas()) > 0, nil
+		}
+		// e.g. quotas.metric=CPUS, quotas.usage>quotas.limit*0.8
+		return filterQuota(t.descend(), g.quota)
+	case "quotaStatusWarning":
+		quotaStatusWarning := g.region.GetQuotaStatusWarning()
+		switch t.AttributeKey {
+		case "code":
+			return t.evaluate(quotaStatusWarning.GetCode())
+		case "message":
+			return t.evaluate(quotaStatusWarning.GetMessage())
+		default:
+			return false, fmt.Errorf("unknown quotaStatusWarning key %v", t.AttributeKey)
+		}
+	case "selfLink":
+		return t.evaluate(g.region.GetSelfLink())
+	case "status":
+		return t.evaluate(g.region.GetStatus())
+	case "supportsPzs":
+		return t.evaluate(strconv.FormatBool(g.region.GetSupportsPzs()))
+	case "zones":
+		return t.evaluateRepeated(g.region.GetZones())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// quotaOperandRegexp matches the values that refer to a key of the same quota optionally multiplied by a
+// number e.g. quotas.limit*0.8
+var quotaOperandRegexp = regexp.MustCompile(`^quotas\.([a-zA-Z]+)(?:\*([-+]?(?:\d+\.?\d*|\.\d+)))?$`)
+
+func filterQuota(t term, quota *computepb.Quota) (bool, error) {
+	switch t.Key {
+	case "limit":
+		return t.evaluateQuotaNumber(quota.GetLimit(), quota)
+	case "metric":
+		return t.evaluate(quota.GetMetric())
+	case "owner":
+		return t.evaluate(quota.GetOwner())
+	case "usage":
+		return t.evaluateQuotaNumber(quota.GetUsage(), quota)
+	default:
+		return false, fmt.Errorf("unknown quotas key %v", t.Key)
+	}
+}
+
+// evaluateQuotaNumber evaluates the limit or usage of the quota. The values can be the limit or usage of
+// the same quota optionally multiplied by a number e.g. quotas.usage>quotas.limit*0.8
+func (t term) evaluateQuotaNumber(number float64, quota *computepb.Quota) (bool, error) {
+	filterValues := t.filterValues()
+	quotaValues := make([]value, len(filterValues))
+	for i, filterValue := range filterValues {
+		quotaValues[i] = filterValue
+		if !strings.HasPrefix(filterValue.text, "quotas.") {
+			continue
+		}
+		matches := quotaOperandRegexp.FindStringSubmatch(filterValue.text)
+		if matches == nil {
+			return false, fmt.Errorf("unsupported quotas value %v. Only a quotas key multiplied by a number is supported e.g. quotas.limit*0.8", filterValue.text)
+		}
+		var operand float64
+		switch matches[1] {
+		case "limit":
+			operand = quota.GetLimit()
+		case "usage":
+			operand = quota.GetUsage()
+		default:
+			return false, fmt.Errorf("quotas key %v cannot be compared with %v", matches[1], t.Key)
+		}
+		if matches[2] != "" {
+			multiplier, err := strconv.ParseFloat(matches[2], 64)
+			if err != nil {
+				return false, err
+			}
+			operand *= multiplier
+		}
+		text := strconv.FormatFloat(operand, 'f', -1, 64)
+		quotaValues[i] = value{Number: &operand, text: text}
+	}
+	t.Value = nil
+	t.ValuesList = &list{Values: quotaValues}
+	return t.evaluate(strconv.FormatFloat(number, 'f', -1, 64))
+}
+
+// FilterRegions filters the given regions according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/regions/list
+//  2. The limit and usage of a quota can be compared with the limit or usage of the same quota optionally
+//     multiplied by a number e.g. quotas.usage>quotas.limit*0.8. Other arithmetic is not supported
+//  3. All the quotas terms are evaluated against the same quota therefore quotas.metric=CPUS AND
+//     quotas.usage>quotas.limit*0.8 matches the regions whose CPUS usage exceeds 80% of their CPUS limit
+func FilterRegions(regions []*computepb.Region, gcpFilter string) ([]*computepb.Region, error) {
+	filteredRegions := make([]*computepb.Region, 0, len(regions))
+	for _, region := range regions {
+		quotas := region.GetQuotas()
+		if len(quotas) == 0 {
+			// The quotas terms of regions without quotas are evaluated against an unset quota
+			quotas = []*computepb.Quota{nil}
+		}
+		// A region is kept if the filter matches any of its quotas as FilterIAMBindings does for the bindings
+		for _, quota := range quotas {
+			regionQuota := resource[gcpRegion]{
+				gcpResource: gcpRegion{region: region, quota: quota},
+				gcpFilter:   gcpFilter,
+			}
+			keepRegion, err := regionQuota.filter()
+			if err != nil {
+				return nil, err
+			}
+			if keepRegion {
+				filteredRegions = append(filteredRegions, region)
+				break
+			}
+		}
+	}
+	return filteredRegions, nil
+}
@@ -0,0 +1,206 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type regionsArray []*computepb.Region
+
+func (r regionsArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, region := range r {
+		sb.WriteString(region.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func toFloat64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestFilterRegions(t *testing.T) {
+	regions := regionsArray{
+		{
+			Name:   toStringPtr("europe-west1"),
+			Status: toStringPtr("UP"),
+			Zones: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b",
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-c",
+			},
+			Quotas: []*computepb.Quota{
+				{
+					Metric: toStringPtr("CPUS"),
+					Limit:  toFloat64Ptr(2400),
+					Usage:  toFloat64Ptr(2100),
+				},
+				{
+					Metric: toStringPtr("IN_USE_ADDRESSES"),
+					Limit:  toFloat64Ptr(100),
+					Usage:  toFloat64Ptr(12),
+				},
+			},
+		},
+		{
+			Name:   toStringPtr("us-central1"),
+			Status: toStringPtr("UP"),
+			Zones: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/us-central1-f",
+			},
+			Quotas: []*computepb.Quota{
+				{
+					Metric: toStringPtr("CPUS"),
+					Limit:  toFloat64Ptr(2400),
+					Usage:  toFloat64Ptr(300),
+				},
+				{
+					Metric: toStringPtr("IN_USE_ADDRESSES"),
+					Limit:  toFloat64Ptr(100),
+					Usage:  toFloat64Ptr(95),
+				},
+				{
+					Metric: toStringPtr("GPUS_ALL_REGIONS"),
+					Limit:  toFloat64Ptr(0),
+					Usage:  toFloat64Ptr(0),
+				},
+			},
+			QuotaStatusWarning: &computepb.QuotaStatusWarning{
+				Code: toStringPtr("UNREACHABLE"),
+			},
+		},
+		{
+			Name:   toStringPtr("asia-east1"),
+			Status: toStringPtr("DOWN"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantRegions regionsArray
+		wantErr     bool
+	}{
+		{
+			name: "Quota usage compared with its limit",
+			args: args{
+				gcpFilter: `quotas.metric=CPUS quotas.usage>quotas.limit*0.8`,
+			},
+			wantRegions: regionsArray{
+				regions[0],
+			},
+		},
+		{
+			name: "Quotas terms of the same quota",
+			args: args{
+				gcpFilter: `quotas.metric=GPUS_ALL_REGIONS AND quotas.usage>0`,
+			},
+			wantRegions: regionsArray{},
+		},
+		{
+			name: "Regions without quotas",
+			args: args{
+				gcpFilter: `NOT quotas:* AND quotas.metric!=CPUS`,
+			},
+			wantRegions: regionsArray{
+				regions[2],
+			},
+		},
+		{
+			name: "Quota usage compared with the limit of the same quota",
+			args: args{
+				gcpFilter: `quotas.usage>=quotas.limit*.9 AND quotas.limit>quotas.usage`,
+			},
+			wantRegions: regionsArray{
+				regions[1],
+			},
+		},
+		{
+			name: "Quota limit compared with its usage",
+			args: args{
+				gcpFilter: `quotas.limit<quotas.usage*1.2`,
+			},
+			wantRegions: regionsArray{
+				regions[0],
+				regions[1],
+			},
+		},
+		{
+			name: "Quota metrics and zones",
+			args: args{
+				gcpFilter: `NOT quotas.metric=GPUS_ALL_REGIONS AND zones:*/europe-west1-c`,
+			},
+			wantRegions: regionsArray{
+				regions[0],
+			},
+		},
+		{
+			name: "Quota status warning",
+			args: args{
+				gcpFilter: `quotaStatusWarning.code:* AND status=UP`,
+			},
+			wantRegions: regionsArray{
+				regions[1],
+			},
+		},
+		{
+			name: "Unsupported quotas arithmetic",
+			args: args{
+				gcpFilter: `quotas.usage>quotas.limit-10`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Quota usage compared with a non numeric key",
+			args: args{
+				gcpFilter: `quotas.usage>quotas.metric`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown quotas key",
+			args: args{
+				gcpFilter: `quotas.foo>1`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRegions, err := FilterRegions(regions, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterRegions() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotRegionsArray := regionsArray(gotRegions)
+			if !reflect.DeepEqual(gotRegionsArray, tt.wantRegions) {
+				t.Errorf("FilterRegions(): \"%v\". want: \"%v\"", gotRegionsArray, tt.wantRegions)
+			}
+			t.Log(gotRegionsArray)
+		})
+	}
+}
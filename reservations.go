@@ -0,0 +1,211 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpReservation struct {
+	reservation *computepb.Reservation
+}
+
+func (g gcpReservation) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "aggregateReservation":
+		aggregateReservation := g.reservation.GetAggregateReservation()
+		// Existence check e.g. aggregateReservation:*
+		if t.AttributeKey == "" && t.existence() {
+			return aggregateReservation != nil, nil
+		}
+		return filterAggregateReservation(t.descend(), aggregateReservation)
+	case "commitment":
+		return t.evaluate(g.reservation.GetCommitment())
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.reservation.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.reservation.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.reservation.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.reservation.GetKind())
+	case "name":
+		return t.evaluate(g.reservation.GetName())
+	case "resourcePolicies":
+		// e.g. resourcePolicies.schedule:*
+		return t.evaluateLabels(g.reservation.GetResourcePolicies())
+	case "resourceStatus":
+		resourceStatus := g.reservation.GetResourceStatus()
+		// Existence check e.g. resourceStatus:*
+		if t.AttributeKey == "" && t.existence() {
+			return resourceStatus != nil, nil
+		}
+		const sourceInstanceTemplateIdKey = "specificSkuAllocation.sourceInstanceTemplateId"
+		if sourceInstanceTemplateIdKey == t.AttributeKey {
+			return t.evaluate(resourceStatus.GetSpecificSkuAllocation().GetSourceInstanceTemplateId())
+		}
+		return false, fmt.Errorf("unknown resourceStatus key %v", t.AttributeKey)
+	case "satisfiesPzs":
+		return t.evaluate(strconv.FormatBool(g.reservation.GetSatisfiesPzs()))
+	case "selfLink":
+		return t.evaluate(g.reservation.GetSelfLink())
+	case "shareSettings":
+		shareSettings := g.reservation.GetShareSettings()
+		// Existence check e.g. shareSettings:*
+		if t.AttributeKey == "" && t.existence() {
+			return shareSettings != nil, nil
+		}
+		return filterShareSettings(t.descend(), shareSettings)
+	case "specificReservation":
+		specificReservation := g.reservation.GetSpecificReservation()
+		// Existence check e.g. specificReservation:*
+		if t.AttributeKey == "" && t.existence() {
+			return specificReservation != nil, nil
+		}
+		return filterSpecificReservation(t.descend(), specificReservation)
+	case "specificReservationRequired":
+		return t.evaluate(strconv.FormatBool(g.reservation.GetSpecificReservationRequired()))
+	case "status":
+		return t.evaluate(g.reservation.GetStatus())
+	case "zone":
+		return t.evaluate(g.reservation.GetZone())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterAggregateReservation(t term, aggregateReservation *computepb.AllocationAggregateReservation) (bool, error) {
+	switch t.Key {
+	case "inUseResources":
+		// e.g. aggregateReservation.inUseResources.accelerator.acceleratorCount>0
+		return filterRepeated(t, aggregateReservation.GetInUseResources(), filterReservedResourceInfo)
+	case "reservedResources":
+		return filterRepeated(t, aggregateReservation.GetReservedResources(), filterReservedResourceInfo)
+	case "vmFamily":
+		return t.evaluate(aggregateReservation.GetVmFamily())
+	case "workloadType":
+		return t.evaluate(aggregateReservation.GetWorkloadType())
+	default:
+		return false, fmt.Errorf("unknown aggregateReservation key %v", t.Key)
+	}
+}
+
+func filterReservedResourceInfo(t term, reservedResourceInfo *computepb.AllocationAggregateReservationReservedResourceInfo) (bool, error) {
+	if t.Key != "accelerator" {
+		return false, fmt.Errorf("unknown resources key %v", t.Key)
+	}
+	accelerator := reservedResourceInfo.GetAccelerator()
+	switch t.AttributeKey {
+	case "acceleratorCount":
+		return t.evaluate(strconv.FormatInt(int64(accelerator.GetAcceleratorCount()), 10))
+	case "acceleratorType":
+		return t.evaluate(accelerator.GetAcceleratorType())
+	default:
+		return false, fmt.Errorf("unknown accelerator key %v", t.AttributeKey)
+	}
+}
+
+func filterShareSettings(t term, shareSettings *computepb.ShareSettings) (bool, error) {
+	switch t.Key {
+	case "projectMap":
+		// e.g. shareSettings.projectMap.appgate-dev:*, shareSettings.projectMap.appgate-dev.projectId=appgate-dev
+		projectKey, attributeKey, _ := strings.Cut(t.AttributeKey, ".")
+		projectConfig, ok := shareSettings.GetProjectMap()[projectKey]
+		const projectIdKey = "projectId"
+		if attributeKey == "" && t.existence() {
+			return ok, nil
+		} else if projectIdKey == attributeKey {
+			return t.evaluate(projectConfig.GetProjectId())
+		}
+		return false, fmt.Errorf("unknown projectMap key %v", t.AttributeKey)
+	case "shareType":
+		return t.evaluate(shareSettings.GetShareType())
+	default:
+		return false, fmt.Errorf("unknown shareSettings key %v", t.Key)
+	}
+}
+
+func filterSpecificReservation(t term, specificReservation *computepb.AllocationSpecificSKUReservation) (bool, error) {
+	switch t.Key {
+	case "assuredCount":
+		return t.evaluate(strconv.FormatInt(specificReservation.GetAssuredCount(), 10))
+	case "count":
+		return t.evaluate(strconv.FormatInt(specificReservation.GetCount(), 10))
+	case "inUseCount":
+		return t.evaluate(strconv.FormatInt(specificReservation.GetInUseCount(), 10))
+	case "instanceProperties":
+		instanceProperties := specificReservation.GetInstanceProperties()
+		// Existence check e.g. specificReservation.instanceProperties:*
+		if t.AttributeKey == "" && t.existence() {
+			return instanceProperties != nil, nil
+		}
+		return filterReservedInstanceProperties(t.descend(), instanceProperties)
+	case "sourceInstanceTemplate":
+		return t.evaluate(specificReservation.GetSourceInstanceTemplate())
+	default:
+		return false, fmt.Errorf("unknown specificReservation key %v", t.Key)
+	}
+}
+
+func filterReservedInstanceProperties(t term, instanceProperties *computepb.AllocationSpecificSKUAllocationReservedInstanceProperties) (bool, error) {
+	switch t.Key {
+	case "guestAccelerators":
+		// e.g. specificReservation.instanceProperties.guestAccelerators.acceleratorType:nvidia-tesla-*
+		return filterRepeated(t, instanceProperties.GetGuestAccelerators(), func(t term, guestAccelerator *computepb.AcceleratorConfig) (bool, error) {
+			switch t.Key {
+			case "acceleratorCount":
+				return t.evaluate(strconv.FormatInt(int64(guestAccelerator.GetAcceleratorCount()), 10))
+			case "acceleratorType":
+				return t.evaluate(guestAccelerator.GetAcceleratorType())
+			default:
+				return false, fmt.Errorf("unknown guestAccelerators key %v", t.Key)
+			}
+		})
+	case "localSsds":
+		return filterRepeated(t, instanceProperties.GetLocalSsds(), func(t term, localSsd *computepb.AllocationSpecificSKUAllocationAllocatedInstancePropertiesReservedDisk) (bool, error) {
+			switch t.Key {
+			case "diskSizeGb":
+				return t.evaluate(strconv.FormatInt(localSsd.GetDiskSizeGb(), 10))
+			case "interface":
+				return t.evaluate(localSsd.GetInterface())
+			default:
+				return false, fmt.Errorf("unknown localSsds key %v", t.Key)
+			}
+		})
+	case "locationHint":
+		return t.evaluate(instanceProperties.GetLocationHint())
+	case "machineType":
+		return t.evaluate(instanceProperties.GetMachineType())
+	case "minCpuPlatform":
+		return t.evaluate(instanceProperties.GetMinCpuPlatform())
+	default:
+		return false, fmt.Errorf("unknown instanceProperties key %v", t.Key)
+	}
+}
+
+// FilterReservations filters the given reservations according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/reservations/list
+func FilterReservations(reservations []*computepb.Reservation, gcpFilter string) ([]*computepb.Reservation, error) {
+	return filterResources(reservations, gcpFilter, func(reservation *computepb.Reservation) gcpReservation {
+		return gcpReservation{reservation: reservation}
+	})
+}
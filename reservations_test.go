@@ -0,0 +1,143 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type reservationsArray []*computepb.Reservation
+
+func (r reservationsArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, reservation := range r {
+		sb.WriteString(reservation.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterReservations(t *testing.T) {
+	reservations := reservationsArray{
+		{
+			Name:              toStringPtr("n2-batch"),
+			Zone:              toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b"),
+			Status:            toStringPtr("READY"),
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			SpecificReservation: &computepb.AllocationSpecificSKUReservation{
+				Count:      toInt64Ptr(20),
+				InUseCount: toInt64Ptr(4),
+				InstanceProperties: &computepb.AllocationSpecificSKUAllocationReservedInstanceProperties{
+					MachineType: toStringPtr("n2-standard-8"),
+				},
+			},
+			SpecificReservationRequired: toBoolPtr(true),
+		},
+		{
+			Name:              toStringPtr("a2-training"),
+			Zone:              toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/us-central1-f"),
+			Status:            toStringPtr("READY"),
+			CreationTimestamp: toStringPtr("2024-03-01T04:00:00.000-08:00"),
+			Commitment:        toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-central1/commitments/gpus"),
+			SpecificReservation: &computepb.AllocationSpecificSKUReservation{
+				Count:      toInt64Ptr(2),
+				InUseCount: toInt64Ptr(2),
+				InstanceProperties: &computepb.AllocationSpecificSKUAllocationReservedInstanceProperties{
+					MachineType: toStringPtr("a2-highgpu-1g"),
+					GuestAccelerators: []*computepb.AcceleratorConfig{
+						{
+							AcceleratorType:  toStringPtr("nvidia-tesla-a100"),
+							AcceleratorCount: toInt32Ptr(1),
+						},
+					},
+				},
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantReservations reservationsArray
+		wantErr          bool
+	}{
+		{
+			name: "Underutilized reservations",
+			args: args{
+				gcpFilter: `status=READY AND specificReservation.count>10 AND specificReservation.inUseCount<5`,
+			},
+			wantReservations: reservationsArray{
+				reservations[0],
+			},
+		},
+		{
+			name: "Machine types and accelerators",
+			args: args{
+				gcpFilter: `specificReservation.instanceProperties.machineType:a2-* AND specificReservation.instanceProperties.guestAccelerators.acceleratorType:nvidia-tesla-*`,
+			},
+			wantReservations: reservationsArray{
+				reservations[1],
+			},
+		},
+		{
+			name: "Commitments and creation timestamp",
+			args: args{
+				gcpFilter: `NOT commitment:* AND specificReservationRequired=true AND creationTimestamp<"2024-01-01T00:00:00Z"`,
+			},
+			wantReservations: reservationsArray{
+				reservations[0],
+			},
+		},
+		{
+			name: "Unknown specific reservation key",
+			args: args{
+				gcpFilter: `specificReservation.foo:bar`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Snake case key",
+			args: args{
+				gcpFilter: `specificReservation.in_use_count=0`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReservations, err := FilterReservations(reservations, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterReservations() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotReservationsArray := reservationsArray(gotReservations)
+			if !reflect.DeepEqual(gotReservationsArray, tt.wantReservations) {
+				t.Errorf("FilterReservations(): \"%v\". want: \"%v\"", gotReservationsArray, tt.wantReservations)
+			}
+			t.Log(gotReservationsArray)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+)
+
+// FieldResolver resolves the fields of custom resource types for filtering
+type FieldResolver interface {
+	// ResolveField returns the value of the field with the given key e.g. scheduling.preemptible
+	// The value can be a string, bool, integer, float, time.Time, Version, fmt.Stringer, a slice of them
+	// for repeated fields e.g. tags, or nil when the field is unset. Unknown keys shall return an error
+	ResolveField(key string) (any, error)
+}
+
+// Version is a version e.g. 1.27.3-gke.100 compared by the precedence of https://semver.org instead of
+// lexically e.g. kubernetesVersion<1.28 is true for Version("1.9.2")
+type Version string
+
+// FieldResolverFunc is an adapter to allow the use of ordinary functions as FieldResolver
+type FieldResolverFunc func(key string) (any, error)
+
+// ResolveField calls f(key)
+func (f FieldResolverFunc) ResolveField(key string) (any, error) {
+	return f(key)
+}
+
+type gcpFieldResolver struct {
+	resolver FieldResolver
+}
+
+func (g gcpFieldResolver) filterTerm(t term) (bool, error) {
+	if t.AttributeKey != "" {
+		t.Key += "." + t.AttributeKey
+		t.AttributeKey = ""
+	}
+	fieldValue, err := g.resolver.ResolveField(t.Key)
+	if err != nil {
+		return false, err
+	}
+	return filterFieldValue(t, reflect.ValueOf(fieldValue))
+}
+
+// Filter filters the given resources of any type according to the gcpFilter resolving their fields
+// with the FieldResolver returned by newResolver
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/topic/filters
+func Filter[T any](resources []T, gcpFilter string, newResolver func(T) FieldResolver) ([]T, error) {
+	return filterResources(resources, gcpFilter, func(resource T) gcpFieldResolver {
+		return gcpFieldResolver{resolver: newResolver(resource)}
+	})
+}
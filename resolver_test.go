@@ -0,0 +1,182 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type server struct {
+	hostname  string
+	cores     int
+	retired   bool
+	purchased time.Time
+	racks     []string
+	owner     *string
+	firmware  string
+}
+
+func (s server) ResolveField(key string) (any, error) {
+	switch key {
+	case "hostname":
+		return s.hostname, nil
+	case "cores":
+		return s.cores, nil
+	case "retired":
+		return s.retired, nil
+	case "purchased":
+		return s.purchased, nil
+	case "racks":
+		return s.racks, nil
+	case "firmware":
+		return Version(s.firmware), nil
+	case "owner.email":
+		if s.owner == nil {
+			return nil, nil
+		}
+		return *s.owner, nil
+	default:
+		return nil, fmt.Errorf("unknown key %v", key)
+	}
+}
+
+type serversArray []server
+
+func (s serversArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, server := range s {
+		sb.WriteString(server.hostname + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilter(t *testing.T) {
+	owner := "ops@example.com"
+	servers := serversArray{
+		{
+			hostname:  "db-01.dc1",
+			cores:     64,
+			purchased: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+			racks:     []string{"r12", "r13"},
+			owner:     &owner,
+			firmware:  "2.10.0",
+		},
+		{
+			hostname:  "web-07.dc2",
+			cores:     8,
+			retired:   true,
+			purchased: time.Date(2016, 6, 15, 0, 0, 0, 0, time.UTC),
+			firmware:  "2.9.1",
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantServers serversArray
+		wantErr     bool
+	}{
+		{
+			name: "Scalars",
+			args: args{
+				gcpFilter: `hostname:*.dc1 AND cores>=32 AND retired=false`,
+			},
+			wantServers: serversArray{
+				servers[0],
+			},
+		},
+		{
+			name: "Times and repeated fields",
+			args: args{
+				gcpFilter: `purchased<"2018-01-01T00:00:00Z" OR racks:r13`,
+			},
+			wantServers: serversArray{
+				servers[0],
+				servers[1],
+			},
+		},
+		{
+			name: "Unset nested field",
+			args: args{
+				gcpFilter: `NOT owner.email:* AND NOT racks:*`,
+			},
+			wantServers: serversArray{
+				servers[1],
+			},
+		},
+		{
+			name: "Versions",
+			args: args{
+				gcpFilter: `firmware>2.9.1 AND firmware<2.10.1-rc.1`,
+			},
+			wantServers: serversArray{
+				servers[0],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `location:dc1`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServers, err := Filter(servers, tt.args.gcpFilter, func(s server) FieldResolver {
+				return s
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Filter() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotServersArray := serversArray(gotServers)
+			if !reflect.DeepEqual(gotServersArray, tt.wantServers) {
+				t.Errorf("Filter(): \"%v\". want: \"%v\"", gotServersArray, tt.wantServers)
+			}
+			t.Log(gotServersArray)
+		})
+	}
+
+	t.Run("FieldResolverFunc", func(t *testing.T) {
+		gotServers, err := Filter(servers, `name:web-*`, func(s server) FieldResolver {
+			return FieldResolverFunc(func(key string) (any, error) {
+				if key == "name" {
+					return s.hostname, nil
+				}
+				return s.ResolveField(key)
+			})
+		})
+		if err != nil {
+			t.Errorf("Filter() error: \"%v\". wantErr: %v", err, false)
+			return
+		}
+		if gotServersArray := serversArray(gotServers); !reflect.DeepEqual(gotServersArray, servers[1:]) {
+			t.Errorf("Filter(): \"%v\". want: \"%v\"", gotServersArray, servers[1:])
+		}
+	})
+}
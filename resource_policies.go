@@ -0,0 +1,233 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpResourcePolicy struct {
+	resourcePolicy *computepb.ResourcePolicy
+}
+
+func (g gcpResourcePolicy) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.resourcePolicy.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.resourcePolicy.GetDescription())
+	case "diskConsistencyGroupPolicy":
+		// Existence check e.g. diskConsistencyGroupPolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return g.resourcePolicy.GetDiskConsistencyGroupPolicy() != nil, nil
+		}
+		return false, fmt.Errorf("unknown diskConsistencyGroupPolicy key %v", t.AttributeKey)
+	case "groupPlacementPolicy":
+		groupPlacementPolicy := g.resourcePolicy.GetGroupPlacementPolicy()
+		// Existence check e.g. groupPlacementPolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return groupPlacementPolicy != nil, nil
+		}
+		switch t.AttributeKey {
+		case "availabilityDomainCount":
+			return t.evaluate(strconv.FormatInt(int64(groupPlacementPolicy.GetAvailabilityDomainCount()), 10))
+		case "collocation":
+			return t.evaluate(groupPlacementPolicy.GetCollocation())
+		case "vmCount":
+			return t.evaluate(strconv.FormatInt(int64(groupPlacementPolicy.GetVmCount()), 10))
+		default:
+			return false, fmt.Errorf("unknown groupPlacementPolicy key %v", t.AttributeKey)
+		}
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.resourcePolicy.GetId(), 10))
+	case "instanceSchedulePolicy":
+		instanceSchedulePolicy := g.resourcePolicy.GetInstanceSchedulePolicy()
+		// Existence check e.g. instanceSchedulePolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return instanceSchedulePolicy != nil, nil
+		}
+		switch t.AttributeKey {
+		case "expirationTime":
+			return t.evaluateTimestampString(instanceSchedulePolicy.GetExpirationTime())
+		case "startTime":
+			return t.evaluateTimestampString(instanceSchedulePolicy.GetStartTime())
+		case "timeZone":
+			// e.g. instanceSchedulePolicy.timeZone:Europe/*
+			return t.evaluate(instanceSchedulePolicy.GetTimeZone())
+		case "vmStartSchedule.schedule":
+			return t.evaluate(instanceSchedulePolicy.GetVmStartSchedule().GetSchedule())
+		case "vmStopSchedule.schedule":
+			return t.evaluate(instanceSchedulePolicy.GetVmStopSchedule().GetSchedule())
+		default:
+			return false, fmt.Errorf("unknown instanceSchedulePolicy key %v", t.AttributeKey)
+		}
+	case "kind":
+		return t.evaluate(g.resourcePolicy.GetKind())
+	case "name":
+		return t.evaluate(g.resourcePolicy.GetName())
+	case "region":
+		return t.evaluate(g.resourcePolicy.GetRegion())
+	case "resourceStatus":
+		instanceSchedulePolicyStatus := g.resourcePolicy.GetResourceStatus().GetInstanceSchedulePolicy()
+		switch t.AttributeKey {
+		case "instanceSchedulePolicy.lastRunStartTime":
+			return t.evaluateTimestampString(instanceSchedulePolicyStatus.GetLastRunStartTime())
+		case "instanceSchedulePolicy.nextRunStartTime":
+			return t.evaluateTimestampString(instanceSchedulePolicyStatus.GetNextRunStartTime())
+		default:
+			return false, fmt.Errorf("unknown resourceStatus key %v", t.AttributeKey)
+		}
+	case "selfLink":
+		return t.evaluate(g.resourcePolicy.GetSelfLink())
+	case "snapshotSchedulePolicy":
+		snapshotSchedulePolicy := g.resourcePolicy.GetSnapshotSchedulePolicy()
+		// Existence check e.g. snapshotSchedulePolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return snapshotSchedulePolicy != nil, nil
+		}
+		return filterSnapshotSchedulePolicy(t.descend(), snapshotSchedulePolicy)
+	case "status":
+		return t.evaluate(g.resourcePolicy.GetStatus())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterSnapshotSchedulePolicy(t term, snapshotSchedulePolicy *computepb.ResourcePolicySnapshotSchedulePolicy) (bool, error) {
+	switch t.Key {
+	case "retentionPolicy":
+		retentionPolicy := snapshotSchedulePolicy.GetRetentionPolicy()
+		// Existence check e.g. snapshotSchedulePolicy.retentionPolicy:*
+		if t.AttributeKey == "" && t.existence() {
+			return retentionPolicy != nil, nil
+		}
+		switch t.AttributeKey {
+		case "maxRetentionDays":
+			// e.g. snapshotSchedulePolicy.retentionPolicy.maxRetentionDays<7
+			return t.evaluate(strconv.FormatInt(int64(retentionPolicy.GetMaxRetentionDays()), 10))
+		case "onSourceDiskDelete":
+			return t.evaluate(retentionPolicy.GetOnSourceDiskDelete())
+		default:
+			return false, fmt.Errorf("unknown retentionPolicy key %v", t.AttributeKey)
+		}
+	case "schedule":
+		schedule := snapshotSchedulePolicy.GetSchedule()
+		// Existence check e.g. snapshotSchedulePolicy.schedule:*
+		if t.AttributeKey == "" && t.existence() {
+			return schedule != nil, nil
+		}
+		return filterSnapshotSchedule(t.descend(), schedule)
+	case "snapshotProperties":
+		snapshotProperties := snapshotSchedulePolicy.GetSnapshotProperties()
+		// Existence check e.g. snapshotSchedulePolicy.snapshotProperties:*
+		if t.AttributeKey == "" && t.existence() {
+			return snapshotProperties != nil, nil
+		}
+		snapshotPropertiesTerm := t.descend()
+		switch snapshotPropertiesTerm.Key {
+		case "chainName":
+			return t.evaluate(snapshotProperties.GetChainName())
+		case "guestFlush":
+			return t.evaluate(strconv.FormatBool(snapshotProperties.GetGuestFlush()))
+		case "labels":
+			// e.g. snapshotSchedulePolicy.snapshotProperties.labels.backup=daily
+			return snapshotPropertiesTerm.evaluateLabels(snapshotProperties.GetLabels())
+		case "storageLocations":
+			return t.evaluateRepeated(snapshotProperties.GetStorageLocations())
+		default:
+			return false, fmt.Errorf("unknown snapshotProperties key %v", snapshotPropertiesTerm.Key)
+		}
+	default:
+		return false, fmt.Errorf("unknown snapshotSchedulePolicy key %v", t.Key)
+	}
+}
+
+// filterSnapshotSchedule filters the hourly, daily or weekly schedule of the snapshots
+// e.g. snapshotSchedulePolicy.schedule.dailySchedule.startTime=04:00
+func filterSnapshotSchedule(t term, schedule *computepb.ResourcePolicySnapshotSchedulePolicySchedule) (bool, error) {
+	switch t.Key {
+	case "dailySchedule":
+		dailySchedule := schedule.GetDailySchedule()
+		// Existence check e.g. snapshotSchedulePolicy.schedule.dailySchedule:*
+		if t.AttributeKey == "" && t.existence() {
+			return dailySchedule != nil, nil
+		}
+		switch t.AttributeKey {
+		case "daysInCycle":
+			return t.evaluate(strconv.FormatInt(int64(dailySchedule.GetDaysInCycle()), 10))
+		case "duration":
+			return t.evaluate(dailySchedule.GetDuration())
+		case "startTime":
+			return t.evaluate(dailySchedule.GetStartTime())
+		default:
+			return false, fmt.Errorf("unknown dailySchedule key %v", t.AttributeKey)
+		}
+	case "hourlySchedule":
+		hourlySchedule := schedule.GetHourlySchedule()
+		// Existence check e.g. snapshotSchedulePolicy.schedule.hourlySchedule:*
+		if t.AttributeKey == "" && t.existence() {
+			return hourlySchedule != nil, nil
+		}
+		switch t.AttributeKey {
+		case "duration":
+			return t.evaluate(hourlySchedule.GetDuration())
+		case "hoursInCycle":
+			return t.evaluate(strconv.FormatInt(int64(hourlySchedule.GetHoursInCycle()), 10))
+		case "startTime":
+			return t.evaluate(hourlySchedule.GetStartTime())
+		default:
+			return false, fmt.Errorf("unknown hourlySchedule key %v", t.AttributeKey)
+		}
+	case "weeklySchedule":
+		weeklySchedule := schedule.GetWeeklySchedule()
+		// Existence check e.g. snapshotSchedulePolicy.schedule.weeklySchedule:*
+		if t.AttributeKey == "" && t.existence() {
+			return weeklySchedule != nil, nil
+		}
+		weeklyScheduleTerm := t.descend()
+		if weeklyScheduleTerm.Key != "dayOfWeeks" {
+			return false, fmt.Errorf("unknown weeklySchedule key %v", weeklyScheduleTerm.Key)
+		}
+		// e.g. snapshotSchedulePolicy.schedule.weeklySchedule.dayOfWeeks.day=SUNDAY
+		return filterRepeated(weeklyScheduleTerm, weeklySchedule.GetDayOfWeeks(), func(t term, dayOfWeek *computepb.ResourcePolicyWeeklyCycleDayOfWeek) (bool, error) {
+			switch t.Key {
+			case "day":
+				return t.evaluate(dayOfWeek.GetDay())
+			case "duration":
+				return t.evaluate(dayOfWeek.GetDuration())
+			case "startTime":
+				return t.evaluate(dayOfWeek.GetStartTime())
+			default:
+				return false, fmt.Errorf("unknown dayOfWeeks key %v", t.Key)
+			}
+		})
+	default:
+		return false, fmt.Errorf("unknown schedule key %v", t.Key)
+	}
+}
+
+// FilterResourcePolicies filters the given resource policies according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/resourcePolicies/list
+func FilterResourcePolicies(resourcePolicies []*computepb.ResourcePolicy, gcpFilter string) ([]*computepb.ResourcePolicy, error) {
+	return filterResources(resourcePolicies, gcpFilter, func(resourcePolicy *computepb.ResourcePolicy) gcpResourcePolicy {
+		return gcpResourcePolicy{resourcePolicy: resourcePolicy}
+	})
+}
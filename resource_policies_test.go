@@ -0,0 +1,126 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type resourcePoliciesArray []*computepb.ResourcePolicy
+
+func (r resourcePoliciesArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, resourcePolicy := range r {
+		sb.WriteString(resourcePolicy.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterResourcePolicies(t *testing.T) {
+	resourcePolicies := resourcePoliciesArray{
+		{
+			Name:   toStringPtr("daily-snapshots"),
+			Region: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status: toStringPtr("READY"),
+			SnapshotSchedulePolicy: &computepb.ResourcePolicySnapshotSchedulePolicy{
+				RetentionPolicy: &computepb.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+					MaxRetentionDays: toInt32Ptr(3),
+				},
+				SnapshotProperties: &computepb.ResourcePolicySnapshotSchedulePolicySnapshotProperties{
+					StorageLocations: []string{"eu"},
+					Labels: map[string]string{
+						"backup": "daily",
+					},
+				},
+			},
+		},
+		{
+			Name:   toStringPtr("spread"),
+			Region: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-central1"),
+			Status: toStringPtr("READY"),
+			GroupPlacementPolicy: &computepb.ResourcePolicyGroupPlacementPolicy{
+				AvailabilityDomainCount: toInt32Ptr(3),
+				Collocation:             toStringPtr("UNSPECIFIED_COLLOCATION"),
+			},
+			CreationTimestamp: toStringPtr("2024-03-01T04:00:00.000-08:00"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantResourcePolicies resourcePoliciesArray
+		wantErr              bool
+	}{
+		{
+			name: "Snapshot schedules",
+			args: args{
+				gcpFilter: `snapshotSchedulePolicy.retentionPolicy.maxRetentionDays<7 AND snapshotSchedulePolicy.snapshotProperties.storageLocations:eu AND snapshotSchedulePolicy.snapshotProperties.labels.backup=daily`,
+			},
+			wantResourcePolicies: resourcePoliciesArray{
+				resourcePolicies[0],
+			},
+		},
+		{
+			name: "Group placement policies",
+			args: args{
+				gcpFilter: `groupPlacementPolicy:* AND groupPlacementPolicy.availabilityDomainCount>=3 AND creationTimestamp>"2024-01-01T00:00:00Z"`,
+			},
+			wantResourcePolicies: resourcePoliciesArray{
+				resourcePolicies[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `foo:bar`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Snake case nested key",
+			args: args{
+				gcpFilter: `snapshotSchedulePolicy.retention_policy.max_retention_days<7`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResourcePolicies, err := FilterResourcePolicies(resourcePolicies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterResourcePolicies() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotResourcePoliciesArray := resourcePoliciesArray(gotResourcePolicies)
+			if !reflect.DeepEqual(gotResourcePoliciesArray, tt.wantResourcePolicies) {
+				t.Errorf("FilterResourcePolicies(): \"%v\". want: \"%v\"", gotResourcePoliciesArray, tt.wantResourcePolicies)
+			}
+			t.Log(gotResourcePoliciesArray)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"reflect"
+
+	"google.golang.org/api/dns/v1"
+)
+
+type gcpResourceRecordSet struct {
+	resourceRecordSet *dns.ResourceRecordSet
+}
+
+func (g gcpResourceRecordSet) filterTerm(t term) (bool, error) {
+	if t.Key == "rrdatas" {
+		if t.AttributeKey != "" {
+			return false, fmt.Errorf("unknown rrdatas key %v", t.AttributeKey)
+		}
+		// e.g. rrdatas:10.0.0.0/8. The data of the records that are not IP addresses e.g. CNAME, MX ones
+		// are evaluated as strings
+		return filterRepeated(t, g.resourceRecordSet.Rrdatas, func(t term, rrdata string) (bool, error) {
+			return t.evaluateIP(rrdata)
+		})
+	}
+	return filterField(t, reflect.ValueOf(g.resourceRecordSet))
+}
+
+// FilterResourceRecordSets filters the given Cloud DNS resource record sets according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/dns/record-sets/list
+//  2. The keys are the ones of https://cloud.google.com/dns/docs/reference/rest/v1/resourceRecordSets#resource:-resourcerecordset
+//  3. The rrdatas are matched against IP addresses and CIDR ranges e.g. rrdatas:10.0.0.0/8. IPv6 ones shall be
+//     quoted e.g. rrdatas:"2600:1900::/28"
+func FilterResourceRecordSets(resourceRecordSets []*dns.ResourceRecordSet, gcpFilter string) ([]*dns.ResourceRecordSet, error) {
+	return filterResources(resourceRecordSets, gcpFilter, func(resourceRecordSet *dns.ResourceRecordSet) gcpResourceRecordSet {
+		return gcpResourceRecordSet{resourceRecordSet: resourceRecordSet}
+	})
+}
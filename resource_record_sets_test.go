@@ -0,0 +1,144 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/dns/v1"
+)
+
+type resourceRecordSetsArray []*dns.ResourceRecordSet
+
+func (r resourceRecordSetsArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, resourceRecordSet := range r {
+		sb.WriteString(resourceRecordSet.Name + "/" + resourceRecordSet.Type + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterResourceRecordSets(t *testing.T) {
+	resourceRecordSets := resourceRecordSetsArray{
+		{
+			Name:    "api.appgate.example.com.",
+			Type:    "A",
+			Ttl:     300,
+			Rrdatas: []string{"34.76.10.2", "34.76.10.3"},
+		},
+		{
+			Name:    "legacy.appgate.example.com.",
+			Type:    "A",
+			Ttl:     3600,
+			Rrdatas: []string{"10.20.0.7"},
+		},
+		{
+			Name:    "www.appgate.example.com.",
+			Type:    "CNAME",
+			Ttl:     60,
+			Rrdatas: []string{"api.appgate.example.com."},
+		},
+		{
+			Name:    "api.appgate.example.com.",
+			Type:    "AAAA",
+			Ttl:     300,
+			Rrdatas: []string{"2600:1900:4000::1"},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		wantResourceRecordSets resourceRecordSetsArray
+		wantErr                bool
+	}{
+		{
+			name: "Records in CIDR ranges",
+			args: args{
+				gcpFilter: `rrdatas:(10.0.0.0/8 "2600:1900::/28")`,
+			},
+			wantResourceRecordSets: resourceRecordSetsArray{
+				resourceRecordSets[1],
+				resourceRecordSets[3],
+			},
+		},
+		{
+			name: "Dangling records",
+			args: args{
+				gcpFilter: `type=(A AAAA) AND rrdatas!=34.76.10.2 AND NOT rrdatas:"2600:1900:4000::1"`,
+			},
+			wantResourceRecordSets: resourceRecordSetsArray{
+				resourceRecordSets[1],
+			},
+		},
+		{
+			name: "Type, TTL and non IP data",
+			args: args{
+				gcpFilter: `type=CNAME AND ttl<300 AND rrdatas:api.*`,
+			},
+			wantResourceRecordSets: resourceRecordSetsArray{
+				resourceRecordSets[2],
+			},
+		},
+		{
+			name: "Numeric TTL",
+			args: args{
+				gcpFilter: `ttl>=300 AND ttl<=3600 AND name:api.*`,
+			},
+			wantResourceRecordSets: resourceRecordSetsArray{
+				resourceRecordSets[0],
+				resourceRecordSets[3],
+			},
+		},
+		{
+			name: "Unknown rrdatas key",
+			args: args{
+				gcpFilter: `rrdatas.foo:10.0.0.1`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResourceRecordSets, err := FilterResourceRecordSets(resourceRecordSets, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterResourceRecordSets() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotResourceRecordSetsArray := resourceRecordSetsArray(gotResourceRecordSets)
+			if !reflect.DeepEqual(gotResourceRecordSetsArray, tt.wantResourceRecordSets) {
+				t.Errorf("FilterResourceRecordSets(): \"%v\". want: \"%v\"", gotResourceRecordSetsArray, tt.wantResourceRecordSets)
+			}
+			t.Log(gotResourceRecordSetsArray)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpRoute struct {
+	route *computepb.Route
+}
+
+func (g gcpRoute) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluate(g.route.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.route.GetDescription())
+	case "destRange":
+		return t.evaluate(g.route.GetDestRange())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.route.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.route.GetKind())
+	case "name":
+		return t.evaluate(g.route.GetName())
+	case "network":
+		return t.evaluate(g.route.GetNetwork())
+	case "nextHopGateway":
+		return t.evaluate(g.route.GetNextHopGateway())
+	case "nextHopHub":
+		return t.evaluate(g.route.GetNextHopHub())
+	case "nextHopIlb":
+		return t.evaluate(g.route.GetNextHopIlb())
+	case "nextHopInstance":
+		return t.evaluate(g.route.GetNextHopInstance())
+	case "nextHopIp":
+		return t.evaluate(g.route.GetNextHopIp())
+	case "nextHopNetwork":
+		return t.evaluate(g.route.GetNextHopNetwork())
+	case "nextHopPeering":
+		return t.evaluate(g.route.GetNextHopPeering())
+	case "nextHopVpnTunnel":
+		return t.evaluate(g.route.GetNextHopVpnTunnel())
+	case "priority":
+		return t.evaluate(strconv.FormatUint(uint64(g.route.GetPriority()), 10))
+	case "routeStatus":
+		return t.evaluate(g.route.GetRouteStatus())
+	case "routeType":
+		return t.evaluate(g.route.GetRouteType())
+	case "selfLink":
+		return t.evaluate(g.route.GetSelfLink())
+	case "tags":
+		// e.g. tags:web, tags:*
+		return t.evaluateRepeated(g.route.GetTags())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterRoutes filters the given routes according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/routes/list
+func FilterRoutes(routes []*computepb.Route, gcpFilter string) ([]*computepb.Route, error) {
+	return filterResources(routes, gcpFilter, func(route *computepb.Route) gcpRoute {
+		return gcpRoute{route: route}
+	})
+}
@@ -0,0 +1,162 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type routesArray []*computepb.Route
+
+func (r routesArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, route := range r {
+		sb.WriteString(route.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func toUint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestFilterRoutes(t *testing.T) {
+	routes := routesArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Description:       toStringPtr("Default route to the Internet."),
+			DestRange:         toStringPtr("0.0.0.0/0"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#route"),
+			Name:              toStringPtr("default-route-internet"),
+			Network:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default"),
+			NextHopGateway:    toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/gateways/default-internet-gateway"),
+			Priority:          toUint32Ptr(1000),
+			RouteType:         toStringPtr("STATIC"),
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			DestRange:         toStringPtr("192.168.0.0/16"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#route"),
+			Name:              toStringPtr("to-onprem"),
+			Network:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			NextHopInstance:   toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/instances/nat-gateway"),
+			NextHopIp:         toStringPtr("10.0.0.5"),
+			Priority:          toUint32Ptr(800),
+			RouteType:         toStringPtr("STATIC"),
+			Tags:              []string{"no-ip", "onprem"},
+		},
+		{
+			CreationTimestamp: toStringPtr("2024-02-11T01:12:21.102-08:00"),
+			DestRange:         toStringPtr("10.0.0.0/24"),
+			Id:                toUint64Ptr(6123462547295123412),
+			Kind:              toStringPtr("compute#route"),
+			Name:              toStringPtr("default-route-subnet"),
+			Network:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			NextHopNetwork:    toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			Priority:          toUint32Ptr(0),
+			RouteType:         toStringPtr("SUBNET"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantRoutes routesArray
+		wantErr    bool
+	}{
+		{
+			name: "Next hop instances",
+			args: args{
+				gcpFilter: `nextHopInstance:* AND tags:onprem`,
+			},
+			wantRoutes: routesArray{
+				routes[1],
+			},
+		},
+		{
+			name: "Destination range and priority",
+			args: args{
+				gcpFilter: `destRange=0.0.0.0/0 OR priority<500`,
+			},
+			wantRoutes: routesArray{
+				routes[0],
+				routes[2],
+			},
+		},
+		{
+			name: "Untagged static routes",
+			args: args{
+				gcpFilter: `routeType=STATIC -tags:* nextHopGateway:*default-internet-gateway`,
+			},
+			wantRoutes: routesArray{
+				routes[0],
+			},
+		},
+		{
+			name: "Negative operator on tags",
+			args: args{
+				gcpFilter: `tags!=no-ip`,
+			},
+			wantRoutes: routesArray{
+				routes[0],
+				routes[2],
+			},
+		},
+		{
+			name: "IP addresses are not versions",
+			args: args{
+				gcpFilter: `nextHopIp=10.0.0 OR nextHopIp="10.0"`,
+			},
+			wantRoutes: routesArray{},
+		},
+		{
+			name: "IP addresses with negative operators",
+			args: args{
+				gcpFilter: `nextHopIp!=10.0.0 AND nextHopIp=10.0.0.5`,
+			},
+			wantRoutes: routesArray{
+				routes[1],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRoutes, err := FilterRoutes(routes, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterRoutes() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotRoutesArray := routesArray(gotRoutes)
+			if !reflect.DeepEqual(gotRoutesArray, tt.wantRoutes) {
+				t.Errorf("FilterRoutes(): \"%v\". want: \"%v\"", gotRoutesArray, tt.wantRoutes)
+			}
+			t.Log(gotRoutesArray)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+)
+
+type gcpRunService struct {
+	service *runpb.Service
+}
+
+func (g gcpRunService) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "annotations":
+		return t.evaluateLabels(g.service.GetAnnotations())
+	case "client":
+		return t.evaluate(g.service.GetClient())
+	case "clientVersion":
+		return t.evaluate(g.service.GetClientVersion())
+	case "conditions":
+		return filterRepeated(t, g.service.GetConditions(), filterRunCondition)
+	case "createTime":
+		return t.evaluateProtoTimestamp(g.service.GetCreateTime())
+	case "creator":
+		return t.evaluate(g.service.GetCreator())
+	case "customAudiences":
+		return t.evaluateRepeated(g.service.GetCustomAudiences())
+	case "defaultUriDisabled":
+		return t.evaluate(strconv.FormatBool(g.service.GetDefaultUriDisabled()))
+	case "deleteTime":
+		return t.evaluateProtoTimestamp(g.service.GetDeleteTime())
+	case "description":
+		return t.evaluate(g.service.GetDescription())
+	case "etag":
+		return t.evaluate(g.service.GetEtag())
+	case "expireTime":
+		return t.evaluateProtoTimestamp(g.service.GetExpireTime())
+	case "generation":
+		return t.evaluate(strconv.FormatInt(g.service.GetGeneration(), 10))
+	case "ingress":
+		return t.evaluate(g.service.GetIngress().String())
+	case "labels":
+		return t.evaluateLabels(g.service.GetLabels())
+	case "lastModifier":
+		return t.evaluate(g.service.GetLastModifier())
+	case "latestCreatedRevision":
+		return t.evaluate(g.service.GetLatestCreatedRevision())
+	case "latestReadyRevision":
+		return t.evaluate(g.service.GetLatestReadyRevision())
+	case "launchStage":
+		return t.evaluate(g.service.GetLaunchStage().String())
+	case "name":
+		return t.evaluate(g.service.GetName())
+	case "observedGeneration":
+		return t.evaluate(strconv.FormatInt(g.service.GetObservedGeneration(), 10))
+	case "reconciling":
+		return t.evaluate(strconv.FormatBool(g.service.GetReconciling()))
+	case "satisfiesPzs":
+		return t.evaluate(strconv.FormatBool(g.service.GetSatisfiesPzs()))
+	case "scaling":
+		const minInstanceCountKey = "minInstanceCount"
+		if minInstanceCountKey == t.AttributeKey {
+			return t.evaluate(strconv.FormatInt(int64(g.service.GetScaling().GetMinInstanceCount()), 10))
+		}
+		return false, fmt.Errorf("unknown scaling key %v", t.AttributeKey)
+	case "template":
+		// e.g. template.containers.image:europe-docker.pkg.dev/*, template.serviceAccount:*
+		return filterRevisionTemplate(t.descend(), g.service.GetTemplate())
+	case "terminalCondition":
+		return filterRunCondition(t.descend(), g.service.GetTerminalCondition())
+	case "traffic":
+		return filterRepeated(t, g.service.GetTraffic(), func(t term, traffic *runpb.TrafficTarget) (bool, error) {
+			switch t.Key {
+			case "percent":
+				return t.evaluate(strconv.FormatInt(int64(traffic.GetPercent()), 10))
+			case "revision":
+				return t.evaluate(traffic.GetRevision())
+			case "tag":
+				return t.evaluate(traffic.GetTag())
+			case "type":
+				return t.evaluate(traffic.GetType().String())
+			default:
+				return false, fmt.Errorf("unknown traffic key %v", t.Key)
+			}
+		})
+	case "uid":
+		return t.evaluate(g.service.GetUid())
+	case "updateTime":
+		return t.evaluateProtoTimestamp(g.service.GetUpdateTime())
+	case "uri":
+		return t.evaluate(g.service.GetUri())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterRevisionTemplate(t term, template *runpb.RevisionTemplate) (bool, error) {
+	switch t.Key {
+	case "annotations":
+		return t.evaluateLabels(template.GetAnnotations())
+	case "containers":
+		return filterRepeated(t, template.GetContainers(), filterRunContainer)
+	case "encryptionKey":
+		return t.evaluate(template.GetEncryptionKey())
+	case "executionEnvironment":
+		return t.evaluate(template.GetExecutionEnvironment().String())
+	case "healthCheckDisabled":
+		return t.evaluate(strconv.FormatBool(template.GetHealthCheckDisabled()))
+	case "labels":
+		return t.evaluateLabels(template.GetLabels())
+	case "maxInstanceRequestConcurrency":
+		return t.evaluate(strconv.FormatInt(int64(template.GetMaxInstanceRequestConcurrency()), 10))
+	case "revision":
+		return t.evaluate(template.GetRevision())
+	case "scaling":
+		scaling := template.GetScaling()
+		switch t.AttributeKey {
+		case "maxInstanceCount":
+			return t.evaluate(strconv.FormatInt(int64(scaling.GetMaxInstanceCount()), 10))
+		case "minInstanceCount":
+			return t.evaluate(strconv.FormatInt(int64(scaling.GetMinInstanceCount()), 10))
+		default:
+			return false, fmt.Errorf("unknown scaling key %v", t.AttributeKey)
+		}
+	case "serviceAccount":
+		return t.evaluate(template.GetServiceAccount())
+	case "sessionAffinity":
+		return t.evaluate(strconv.FormatBool(template.GetSessionAffinity()))
+	case "timeout":
+		return t.evaluateProtoDuration(template.GetTimeout())
+	case "vpcAccess":
+		vpcAccess := template.GetVpcAccess()
+		switch t.AttributeKey {
+		case "connector":
+			return t.evaluate(vpcAccess.GetConnector())
+		case "egress":
+			return t.evaluate(vpcAccess.GetEgress().String())
+		default:
+			return false, fmt.Errorf("unknown vpcAccess key %v", t.AttributeKey)
+		}
+	default:
+		return false, fmt.Errorf("unknown template key %v", t.Key)
+	}
+}
+
+func filterRunContainer(t term, container *runpb.Container) (bool, error) {
+	switch t.Key {
+	case "args":
+		return t.evaluateRepeated(container.GetArgs())
+	case "command":
+		return t.evaluateRepeated(container.GetCommand())
+	case "dependsOn":
+		return t.evaluateRepeated(container.GetDependsOn())
+	case "env":
+		// e.g. template.containers.env.name=LOG_LEVEL
+		return filterRepeated(t, container.GetEnv(), func(t term, env *runpb.EnvVar) (bool, error) {
+			switch t.Key {
+			case "name":
+				return t.evaluate(env.GetName())
+			case "value":
+				return t.evaluate(env.GetValue())
+			default:
+				return false, fmt.Errorf("unknown env key %v", t.Key)
+			}
+		})
+	case "image":
+		return t.evaluate(container.GetImage())
+	case "name":
+		return t.evaluate(container.GetName())
+	case "ports":
+		return filterRepeated(t, container.GetPorts(), func(t term, port *runpb.ContainerPort) (bool, error) {
+			switch t.Key {
+			case "containerPort":
+				return t.evaluate(strconv.FormatInt(int64(port.GetContainerPort()), 10))
+			case "name":
+				return t.evaluate(port.GetName())
+			default:
+				return false, fmt.Errorf("unknown ports key %v", t.Key)
+			}
+		})
+	case "resources":
+		resources := container.GetResources()
+		resourcesTerm := t.descend()
+		switch resourcesTerm.Key {
+		case "cpuIdle":
+			return resourcesTerm.evaluate(strconv.FormatBool(resources.GetCpuIdle()))
+		case "limits":
+			// e.g. template.containers.resources.limits.memory=512Mi
+			return resourcesTerm.evaluateLabels(resources.GetLimits())
+		case "startupCpuBoost":
+			return resourcesTerm.evaluate(strconv.FormatBool(resources.GetStartupCpuBoost()))
+		default:
+			return false, fmt.Errorf("unknown resources key %v", resourcesTerm.Key)
+		}
+	case "workingDir":
+		return t.evaluate(container.GetWorkingDir())
+	default:
+		return false, fmt.Errorf("unknown containers key %v", t.Key)
+	}
+}
+
+func filterRunCondition(t term, condition *runpb.Condition) (bool, error) {
+	switch t.Key {
+	case "lastTransitionTime":
+		return t.evaluateProtoTimestamp(condition.GetLastTransitionTime())
+	case "message":
+		return t.evaluate(condition.GetMessage())
+	case "severity":
+		return t.evaluate(condition.GetSeverity().String())
+	case "state":
+		return t.evaluate(condition.GetState().String())
+	case "type":
+		return t.evaluate(condition.GetType())
+	default:
+		return false, fmt.Errorf("unknown condition key %v", t.Key)
+	}
+}
+
+// FilterRunServices filters the given Cloud Run services according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/run/docs/reference/rest/v2/projects.locations.services
+func FilterRunServices(services []*runpb.Service, gcpFilter string) ([]*runpb.Service, error) {
+	return filterResources(services, gcpFilter, func(service *runpb.Service) gcpRunService {
+		return gcpRunService{service: service}
+	})
+}
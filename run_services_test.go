@@ -0,0 +1,199 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/genproto/googleapis/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type runServicesArray []*runpb.Service
+
+func (r runServicesArray) String() string {
+	if len(r) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, service := range r {
+		sb.WriteString(service.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterRunServices(t *testing.T) {
+	services := runServicesArray{
+		{
+			Name:        "projects/appgate-dev/locations/europe-west1/services/api",
+			Uri:         "https://api-abcdefghij-ew.a.run.app",
+			Ingress:     runpb.IngressTraffic_INGRESS_TRAFFIC_ALL,
+			LaunchStage: api.LaunchStage_GA,
+			Labels: map[string]string{
+				"team": "platform",
+			},
+			Annotations: map[string]string{
+				"owner": "alice",
+			},
+			Template: &runpb.RevisionTemplate{
+				ServiceAccount: "api@appgate-dev.iam.gserviceaccount.com",
+				Timeout:        durationpb.New(15 * time.Minute),
+				Scaling: &runpb.RevisionScaling{
+					MaxInstanceCount: 100,
+				},
+				Containers: []*runpb.Container{
+					{
+						Image: "europe-docker.pkg.dev/appgate-dev/images/api:1.4.2",
+						Env: []*runpb.EnvVar{
+							{
+								Name:   "LOG_LEVEL",
+								Values: &runpb.EnvVar_Value{Value: "debug"},
+							},
+						},
+						Resources: &runpb.ResourceRequirements{
+							Limits: map[string]string{
+								"memory": "512Mi",
+							},
+						},
+					},
+				},
+			},
+			TerminalCondition: &runpb.Condition{
+				Type:  "Ready",
+				State: runpb.Condition_CONDITION_SUCCEEDED,
+			},
+		},
+		{
+			Name:        "projects/appgate-dev/locations/us-central1/services/internal-admin",
+			Uri:         "https://internal-admin-abcdefghij-uc.a.run.app",
+			Ingress:     runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY,
+			LaunchStage: api.LaunchStage_BETA,
+			Template: &runpb.RevisionTemplate{
+				Containers: []*runpb.Container{
+					{
+						Image: "gcr.io/appgate-dev/admin:latest",
+					},
+					{
+						Image: "gcr.io/cloudsql-docker/gce-proxy:1.33",
+					},
+				},
+			},
+			TerminalCondition: &runpb.Condition{
+				Type:  "Ready",
+				State: runpb.Condition_CONDITION_FAILED,
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantRunServices runServicesArray
+		wantErr         bool
+	}{
+		{
+			name: "Ingress and launch stage",
+			args: args{
+				gcpFilter: `ingress=INGRESS_TRAFFIC_ALL AND launchStage=GA AND uri:*.run.app`,
+			},
+			wantRunServices: runServicesArray{
+				services[0],
+			},
+		},
+		{
+			name: "Container images",
+			args: args{
+				gcpFilter: `template.containers.image:gcr.io/* AND NOT template.serviceAccount:*`,
+			},
+			wantRunServices: runServicesArray{
+				services[1],
+			},
+		},
+		{
+			name: "Containers env and limits",
+			args: args{
+				gcpFilter: `template.containers.env.name=LOG_LEVEL AND template.containers.resources.limits.memory=512Mi AND template.scaling.maxInstanceCount>=100`,
+			},
+			wantRunServices: runServicesArray{
+				services[0],
+			},
+		},
+		{
+			name: "Labels, annotations and conditions",
+			args: args{
+				gcpFilter: `labels.team=platform OR annotations.owner:* OR terminalCondition.state=CONDITION_FAILED`,
+			},
+			wantRunServices: runServicesArray{
+				services[0],
+				services[1],
+			},
+		},
+		{
+			name: "Timeout",
+			args: args{
+				gcpFilter: `template.timeout>10m AND template.timeout<=PT15M AND template.timeout=900`,
+			},
+			wantRunServices: runServicesArray{
+				services[0],
+			},
+		},
+		{
+			name: "Timeout unset",
+			args: args{
+				gcpFilter: `-template.timeout:*`,
+			},
+			wantRunServices: runServicesArray{
+				services[1],
+			},
+		},
+		{
+			name: "Invalid timeout",
+			args: args{
+				gcpFilter: `template.timeout>10x`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown template key",
+			args: args{
+				gcpFilter: `template.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRunServices, err := FilterRunServices(services, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterRunServices() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotRunServicesArray := runServicesArray(gotRunServices)
+			if !reflect.DeepEqual(gotRunServicesArray, tt.wantRunServices) {
+				t.Errorf("FilterRunServices(): \"%v\". want: \"%v\"", gotRunServicesArray, tt.wantRunServices)
+			}
+			t.Log(gotRunServicesArray)
+		})
+	}
+}
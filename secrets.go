@@ -0,0 +1,152 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+type gcpSecret struct {
+	secret *secretmanagerpb.Secret
+}
+
+func (g gcpSecret) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "annotations":
+		return t.evaluateLabels(g.secret.GetAnnotations())
+	case "createTime":
+		return t.evaluateProtoTimestamp(g.secret.GetCreateTime())
+	case "customerManagedEncryption":
+		return filterCustomerManagedEncryption(t, g.secret.GetCustomerManagedEncryption())
+	case "etag":
+		return t.evaluate(g.secret.GetEtag())
+	case "expireTime":
+		return t.evaluateProtoTimestamp(g.secret.GetExpireTime())
+	case "labels":
+		return t.evaluateLabels(g.secret.GetLabels())
+	case "name":
+		return t.evaluate(g.secret.GetName())
+	case "replication":
+		replication := g.secret.GetReplication()
+		// Existence check e.g. replication:*
+		if t.AttributeKey == "" && t.existence() {
+			return replication != nil, nil
+		}
+		return filterReplication(t.descend(), replication)
+	case "rotation":
+		rotation := g.secret.GetRotation()
+		// Existence check e.g. rotation:*
+		if t.AttributeKey == "" && t.existence() {
+			return rotation != nil, nil
+		}
+		switch t.AttributeKey {
+		case "nextRotationTime":
+			return t.evaluateProtoTimestamp(rotation.GetNextRotationTime())
+		case "rotationPeriod":
+			return t.evaluateProtoDuration(rotation.GetRotationPeriod())
+		default:
+			return false, fmt.Errorf("unknown rotation key %v", t.AttributeKey)
+		}
+	case "topics":
+		const nameKey = "name"
+		if t.AttributeKey == "" || nameKey == t.AttributeKey {
+			topics := make([]string, 0, len(g.secret.GetTopics()))
+			for _, topic := range g.secret.GetTopics() {
+				topics = append(topics, topic.GetName())
+			}
+			return t.evaluateRepeated(topics)
+		}
+		return false, fmt.Errorf("unknown topics key %v", t.AttributeKey)
+	case "ttl":
+		return t.evaluateProtoDuration(g.secret.GetTtl())
+	case "versionAliases":
+		versionAliases := make(map[string]string, len(g.secret.GetVersionAliases()))
+		for alias, version := range g.secret.GetVersionAliases() {
+			versionAliases[alias] = strconv.FormatInt(version, 10)
+		}
+		return t.evaluateLabels(versionAliases)
+	case "versionDestroyTtl":
+		return t.evaluateProtoDuration(g.secret.GetVersionDestroyTtl())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterReplication(t term, replication *secretmanagerpb.Replication) (bool, error) {
+	switch t.Key {
+	case "automatic":
+		automatic := replication.GetAutomatic()
+		// Existence check e.g. replication.automatic:*
+		if t.AttributeKey == "" && t.existence() {
+			return automatic != nil, nil
+		}
+		automaticTerm := t.descend()
+		if automaticTerm.Key == "customerManagedEncryption" {
+			return filterCustomerManagedEncryption(automaticTerm, automatic.GetCustomerManagedEncryption())
+		}
+		return false, fmt.Errorf("unknown automatic key %v", automaticTerm.Key)
+	case "userManaged":
+		userManaged := replication.GetUserManaged()
+		// Existence check e.g. replication.userManaged:*
+		if t.AttributeKey == "" && t.existence() {
+			return userManaged != nil, nil
+		}
+		userManagedTerm := t.descend()
+		if userManagedTerm.Key == "replicas" {
+			return filterRepeated(userManagedTerm, userManaged.GetReplicas(), filterReplica)
+		}
+		return false, fmt.Errorf("unknown userManaged key %v", userManagedTerm.Key)
+	default:
+		return false, fmt.Errorf("unknown replication key %v", t.Key)
+	}
+}
+
+func filterReplica(t term, replica *secretmanagerpb.Replication_UserManaged_Replica) (bool, error) {
+	switch t.Key {
+	case "customerManagedEncryption":
+		return filterCustomerManagedEncryption(t, replica.GetCustomerManagedEncryption())
+	case "location":
+		return t.evaluate(replica.GetLocation())
+	default:
+		return false, fmt.Errorf("unknown replicas key %v", t.Key)
+	}
+}
+
+func filterCustomerManagedEncryption(t term, customerManagedEncryption *secretmanagerpb.CustomerManagedEncryption) (bool, error) {
+	// Existence check e.g. replication.automatic.customerManagedEncryption:*
+	if t.AttributeKey == "" && t.existence() {
+		return customerManagedEncryption != nil, nil
+	}
+	const kmsKeyNameKey = "kmsKeyName"
+	if kmsKeyNameKey == t.AttributeKey {
+		return t.evaluate(customerManagedEncryption.GetKmsKeyName())
+	}
+	return false, fmt.Errorf("unknown customerManagedEncryption key %v", t.AttributeKey)
+}
+
+// FilterSecrets filters the given Secret Manager secrets according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/secrets/list
+//  2. topics matches the names of the topics e.g. topics:*/topics/rotation
+func FilterSecrets(secrets []*secretmanagerpb.Secret, gcpFilter string) ([]*secretmanagerpb.Secret, error) {
+	return filterResources(secrets, gcpFilter, func(secret *secretmanagerpb.Secret) gcpSecret {
+		return gcpSecret{secret: secret}
+	})
+}
@@ -0,0 +1,169 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type secretsArray []*secretmanagerpb.Secret
+
+func (s secretsArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, secret := range s {
+		sb.WriteString(secret.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterSecrets(t *testing.T) {
+	secrets := secretsArray{
+		{
+			Name: "projects/appgate-dev/secrets/db-password",
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_UserManaged_{
+					UserManaged: &secretmanagerpb.Replication_UserManaged{
+						Replicas: []*secretmanagerpb.Replication_UserManaged_Replica{
+							{
+								Location: "europe-west1",
+								CustomerManagedEncryption: &secretmanagerpb.CustomerManagedEncryption{
+									KmsKeyName: "projects/appgate-dev/locations/europe-west1/keyRings/secrets/cryptoKeys/db",
+								},
+							},
+							{
+								Location: "europe-west4",
+							},
+						},
+					},
+				},
+			},
+			Topics: []*secretmanagerpb.Topic{
+				{
+					Name: "projects/appgate-dev/topics/secret-rotation",
+				},
+			},
+			Rotation: &secretmanagerpb.Rotation{
+				NextRotationTime: timestamppb.New(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+				RotationPeriod:   durationpb.New(30 * 24 * time.Hour),
+			},
+			VersionAliases: map[string]int64{
+				"current": 4,
+			},
+			Labels: map[string]string{
+				"team": "data",
+			},
+		},
+		{
+			Name: "projects/appgate-dev/secrets/temporary-token",
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+			Expiration: &secretmanagerpb.Secret_ExpireTime{
+				ExpireTime: timestamppb.New(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantSecrets secretsArray
+		wantErr     bool
+	}{
+		{
+			name: "User managed replicas",
+			args: args{
+				gcpFilter: `replication.userManaged.replicas.location=europe-west4 AND replication.userManaged.replicas.customerManagedEncryption.kmsKeyName:*/cryptoKeys/db`,
+			},
+			wantSecrets: secretsArray{
+				secrets[0],
+			},
+		},
+		{
+			name: "Automatic replication and expiration",
+			args: args{
+				gcpFilter: `replication.automatic:* AND expireTime<"2024-03-01T00:00:00Z" AND -rotation.rotationPeriod:*`,
+			},
+			wantSecrets: secretsArray{
+				secrets[1],
+			},
+		},
+		{
+			name: "Topics, rotation and version aliases",
+			args: args{
+				gcpFilter: `topics:*/topics/secret-rotation AND rotation.rotationPeriod<=720h AND versionAliases.current>=4 AND labels.team=data`,
+			},
+			wantSecrets: secretsArray{
+				secrets[0],
+			},
+		},
+		{
+			name: "Secrets without topics",
+			args: args{
+				gcpFilter: `NOT topics:*`,
+			},
+			wantSecrets: secretsArray{
+				secrets[1],
+			},
+		},
+		{
+			name: "Nested messages existence",
+			args: args{
+				gcpFilter: `replication:* AND NOT rotation:* AND NOT replication.automatic.customerManagedEncryption:*`,
+			},
+			wantSecrets: secretsArray{
+				secrets[1],
+			},
+		},
+		{
+			name: "Unknown replication key",
+			args: args{
+				gcpFilter: `replication.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSecrets, err := FilterSecrets(secrets, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSecrets() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotSecretsArray := secretsArray(gotSecrets)
+			if !reflect.DeepEqual(gotSecretsArray, tt.wantSecrets) {
+				t.Errorf("FilterSecrets(): \"%v\". want: \"%v\"", gotSecretsArray, tt.wantSecrets)
+			}
+			t.Log(gotSecretsArray)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/iam/admin/apiv1/adminpb"
+)
+
+type gcpServiceAccountKey struct {
+	serviceAccountKey *adminpb.ServiceAccountKey
+}
+
+func (g gcpServiceAccountKey) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "disabled":
+		return t.evaluate(strconv.FormatBool(g.serviceAccountKey.GetDisabled()))
+	case "keyAlgorithm":
+		return t.evaluate(g.serviceAccountKey.GetKeyAlgorithm().String())
+	case "keyOrigin":
+		return t.evaluate(g.serviceAccountKey.GetKeyOrigin().String())
+	case "keyType":
+		return t.evaluate(g.serviceAccountKey.GetKeyType().String())
+	case "name":
+		return t.evaluate(g.serviceAccountKey.GetName())
+	case "privateKeyType":
+		return t.evaluate(g.serviceAccountKey.GetPrivateKeyType().String())
+	case "validAfterTime":
+		return t.evaluateProtoTimestamp(g.serviceAccountKey.GetValidAfterTime())
+	case "validBeforeTime":
+		return t.evaluateProtoTimestamp(g.serviceAccountKey.GetValidBeforeTime())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterServiceAccountKeys filters the given IAM service account keys according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/iam/service-accounts/keys/list
+//  2. The key material i.e. privateKeyData and publicKeyData cannot be filtered
+func FilterServiceAccountKeys(serviceAccountKeys []*adminpb.ServiceAccountKey, gcpFilter string) ([]*adminpb.ServiceAccountKey, error) {
+	return filterResources(serviceAccountKeys, gcpFilter, func(serviceAccountKey *adminpb.ServiceAccountKey) gcpServiceAccountKey {
+		return gcpServiceAccountKey{serviceAccountKey: serviceAccountKey}
+	})
+}
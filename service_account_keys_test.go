@@ -0,0 +1,156 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/iam/admin/apiv1/adminpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type serviceAccountKeysArray []*adminpb.ServiceAccountKey
+
+func (s serviceAccountKeysArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, serviceAccountKey := range s {
+		sb.WriteString(serviceAccountKey.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterServiceAccountKeys(t *testing.T) {
+	serviceAccountKeys := serviceAccountKeysArray{
+		{
+			Name:            "projects/appgate-dev/serviceAccounts/ci-deployer@appgate-dev.iam.gserviceaccount.com/keys/1a2b3c",
+			KeyAlgorithm:    adminpb.ServiceAccountKeyAlgorithm_KEY_ALG_RSA_2048,
+			KeyOrigin:       adminpb.ServiceAccountKeyOrigin_GOOGLE_PROVIDED,
+			KeyType:         adminpb.ListServiceAccountKeysRequest_USER_MANAGED,
+			ValidAfterTime:  timestamppb.New(time.Date(2022, 5, 10, 8, 0, 0, 0, time.UTC)),
+			ValidBeforeTime: timestamppb.New(time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)),
+		},
+		{
+			Name:            "projects/appgate-dev/serviceAccounts/ci-deployer@appgate-dev.iam.gserviceaccount.com/keys/4d5e6f",
+			KeyAlgorithm:    adminpb.ServiceAccountKeyAlgorithm_KEY_ALG_RSA_2048,
+			KeyOrigin:       adminpb.ServiceAccountKeyOrigin_GOOGLE_PROVIDED,
+			KeyType:         adminpb.ListServiceAccountKeysRequest_SYSTEM_MANAGED,
+			ValidAfterTime:  timestamppb.New(time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)),
+			ValidBeforeTime: timestamppb.New(time.Date(2024, 1, 18, 8, 0, 0, 0, time.UTC)),
+		},
+		{
+			Name:           "projects/devops-test/serviceAccounts/old-backup@devops-test.iam.gserviceaccount.com/keys/7a8b9c",
+			KeyAlgorithm:   adminpb.ServiceAccountKeyAlgorithm_KEY_ALG_RSA_1024,
+			KeyOrigin:      adminpb.ServiceAccountKeyOrigin_USER_PROVIDED,
+			KeyType:        adminpb.ListServiceAccountKeysRequest_USER_MANAGED,
+			ValidAfterTime: timestamppb.New(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)),
+			Disabled:       true,
+		},
+		{
+			Name:            "projects/appgate-dev/serviceAccounts/ci-deployer@appgate-dev.iam.gserviceaccount.com/keys/0d1e2f",
+			KeyAlgorithm:    adminpb.ServiceAccountKeyAlgorithm_KEY_ALG_RSA_2048,
+			KeyOrigin:       adminpb.ServiceAccountKeyOrigin_GOOGLE_PROVIDED,
+			KeyType:         adminpb.ListServiceAccountKeysRequest_USER_MANAGED,
+			ValidAfterTime:  timestamppb.New(time.Now().Add(-24 * time.Hour)),
+			ValidBeforeTime: timestamppb.New(time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		wantServiceAccountKeys serviceAccountKeysArray
+		wantErr                bool
+	}{
+		{
+			name: "Old user managed keys",
+			args: args{
+				gcpFilter: `keyType=USER_MANAGED AND validAfterTime<"2023-10-01T00:00:00Z" AND disabled=false`,
+			},
+			wantServiceAccountKeys: serviceAccountKeysArray{
+				serviceAccountKeys[0],
+			},
+		},
+		{
+			name: "Key origin and algorithm",
+			args: args{
+				gcpFilter: `keyOrigin=USER_PROVIDED OR keyAlgorithm:KEY_ALG_RSA_1024`,
+			},
+			wantServiceAccountKeys: serviceAccountKeysArray{
+				serviceAccountKeys[2],
+			},
+		},
+		{
+			name: "Valid before time and its existence",
+			args: args{
+				gcpFilter: `validBeforeTime<"2025-01-01T00:00:00+01:00" OR NOT validBeforeTime:*`,
+			},
+			wantServiceAccountKeys: serviceAccountKeysArray{
+				serviceAccountKeys[1],
+				serviceAccountKeys[2],
+			},
+		},
+		{
+			name: "User managed keys older than 90 days",
+			args: args{
+				gcpFilter: `keyType=USER_MANAGED AND validAfterTime<-P90D`,
+			},
+			wantServiceAccountKeys: serviceAccountKeysArray{
+				serviceAccountKeys[0],
+				serviceAccountKeys[2],
+			},
+		},
+		{
+			name: "Keys of the last week",
+			args: args{
+				gcpFilter: `validAfterTime>=-P1W AND validBeforeTime>P1Y`,
+			},
+			wantServiceAccountKeys: serviceAccountKeysArray{
+				serviceAccountKeys[3],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `privateKeyData:*`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServiceAccountKeys, err := FilterServiceAccountKeys(serviceAccountKeys, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterServiceAccountKeys() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotServiceAccountKeysArray := serviceAccountKeysArray(gotServiceAccountKeys)
+			if !reflect.DeepEqual(gotServiceAccountKeysArray, tt.wantServiceAccountKeys) {
+				t.Errorf("FilterServiceAccountKeys(): \"%v\". want: \"%v\"", gotServiceAccountKeysArray, tt.wantServiceAccountKeys)
+			}
+			t.Log(gotServiceAccountKeysArray)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/iam/admin/apiv1/adminpb"
+)
+
+type gcpServiceAccount struct {
+	serviceAccount *adminpb.ServiceAccount
+}
+
+func (g gcpServiceAccount) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "description":
+		return t.evaluate(g.serviceAccount.GetDescription())
+	case "disabled":
+		return t.evaluate(strconv.FormatBool(g.serviceAccount.GetDisabled()))
+	case "displayName":
+		return t.evaluate(g.serviceAccount.GetDisplayName())
+	case "email":
+		return t.evaluate(g.serviceAccount.GetEmail())
+	case "name":
+		return t.evaluate(g.serviceAccount.GetName())
+	case "oauth2ClientId":
+		return t.evaluate(g.serviceAccount.GetOauth2ClientId())
+	case "projectId":
+		return t.evaluate(g.serviceAccount.GetProjectId())
+	case "uniqueId":
+		return t.evaluate(g.serviceAccount.GetUniqueId())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterServiceAccounts filters the given IAM service accounts according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/iam/service-accounts/list
+func FilterServiceAccounts(serviceAccounts []*adminpb.ServiceAccount, gcpFilter string) ([]*adminpb.ServiceAccount, error) {
+	return filterResources(serviceAccounts, gcpFilter, func(serviceAccount *adminpb.ServiceAccount) gcpServiceAccount {
+		return gcpServiceAccount{serviceAccount: serviceAccount}
+	})
+}
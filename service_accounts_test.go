@@ -0,0 +1,117 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/iam/admin/apiv1/adminpb"
+)
+
+type serviceAccountsArray []*adminpb.ServiceAccount
+
+func (s serviceAccountsArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, serviceAccount := range s {
+		sb.WriteString(serviceAccount.GetEmail() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterServiceAccounts(t *testing.T) {
+	serviceAccounts := serviceAccountsArray{
+		{
+			Name:           "projects/appgate-dev/serviceAccounts/ci-deployer@appgate-dev.iam.gserviceaccount.com",
+			ProjectId:      "appgate-dev",
+			UniqueId:       "112233445566778899001",
+			Email:          "ci-deployer@appgate-dev.iam.gserviceaccount.com",
+			DisplayName:    "CI deployer",
+			Oauth2ClientId: "112233445566778899001",
+		},
+		{
+			Name:           "projects/appgate-dev/serviceAccounts/82699087620-compute@developer.gserviceaccount.com",
+			ProjectId:      "appgate-dev",
+			UniqueId:       "998877665544332211001",
+			Email:          "82699087620-compute@developer.gserviceaccount.com",
+			DisplayName:    "Compute Engine default service account",
+			Oauth2ClientId: "998877665544332211001",
+		},
+		{
+			Name:        "projects/devops-test/serviceAccounts/old-backup@devops-test.iam.gserviceaccount.com",
+			ProjectId:   "devops-test",
+			Email:       "old-backup@devops-test.iam.gserviceaccount.com",
+			DisplayName: "Backups (deprecated)",
+			Disabled:    true,
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                string
+		args                args
+		wantServiceAccounts serviceAccountsArray
+		wantErr             bool
+	}{
+		{
+			name: "Default service accounts",
+			args: args{
+				gcpFilter: `email:*-compute@developer.gserviceaccount.com OR displayName:"Compute Engine default*"`,
+			},
+			wantServiceAccounts: serviceAccountsArray{
+				serviceAccounts[1],
+			},
+		},
+		{
+			name: "Disabled and project",
+			args: args{
+				gcpFilter: `disabled=true OR (projectId=appgate-dev AND oauth2ClientId=112233445566778899001)`,
+			},
+			wantServiceAccounts: serviceAccountsArray{
+				serviceAccounts[0],
+				serviceAccounts[2],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `keys:*`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServiceAccounts, err := FilterServiceAccounts(serviceAccounts, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterServiceAccounts() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotServiceAccountsArray := serviceAccountsArray(gotServiceAccounts)
+			if !reflect.DeepEqual(gotServiceAccountsArray, tt.wantServiceAccounts) {
+				t.Errorf("FilterServiceAccounts(): \"%v\". want: \"%v\"", gotServiceAccountsArray, tt.wantServiceAccounts)
+			}
+			t.Log(gotServiceAccountsArray)
+		})
+	}
+}
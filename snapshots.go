@@ -0,0 +1,92 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpSnapshot struct {
+	snapshot *computepb.Snapshot
+}
+
+func (g gcpSnapshot) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "architecture":
+		return t.evaluate(g.snapshot.GetArchitecture())
+	case "autoCreated":
+		return t.evaluate(strconv.FormatBool(g.snapshot.GetAutoCreated()))
+	case "chainName":
+		return t.evaluate(g.snapshot.GetChainName())
+	case "creationSizeBytes":
+		return t.evaluate(strconv.FormatInt(g.snapshot.GetCreationSizeBytes(), 10))
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.snapshot.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.snapshot.GetDescription())
+	case "diskSizeGb":
+		return t.evaluate(strconv.FormatInt(g.snapshot.GetDiskSizeGb(), 10))
+	case "downloadBytes":
+		return t.evaluate(strconv.FormatInt(g.snapshot.GetDownloadBytes(), 10))
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.snapshot.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.snapshot.GetKind())
+	case "labelFingerprint":
+		return t.evaluate(g.snapshot.GetLabelFingerprint())
+	case "labels":
+		return t.evaluateLabels(g.snapshot.GetLabels())
+	case "licenses":
+		return t.evaluateRepeated(g.snapshot.GetLicenses())
+	case "locationHint":
+		return t.evaluate(g.snapshot.GetLocationHint())
+	case "name":
+		return t.evaluate(g.snapshot.GetName())
+	case "selfLink":
+		return t.evaluate(g.snapshot.GetSelfLink())
+	case "snapshotType":
+		return t.evaluate(g.snapshot.GetSnapshotType())
+	case "sourceDisk":
+		return t.evaluate(g.snapshot.GetSourceDisk())
+	case "sourceDiskId":
+		return t.evaluate(g.snapshot.GetSourceDiskId())
+	case "sourceSnapshotSchedulePolicy":
+		return t.evaluate(g.snapshot.GetSourceSnapshotSchedulePolicy())
+	case "status":
+		return t.evaluate(g.snapshot.GetStatus())
+	case "storageBytes":
+		return t.evaluate(strconv.FormatInt(g.snapshot.GetStorageBytes(), 10))
+	case "storageBytesStatus":
+		return t.evaluate(g.snapshot.GetStorageBytesStatus())
+	case "storageLocations":
+		return t.evaluateRepeated(g.snapshot.GetStorageLocations())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterSnapshots filters the given snapshots according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/list
+func FilterSnapshots(snapshots []*computepb.Snapshot, gcpFilter string) ([]*computepb.Snapshot, error) {
+	return filterResources(snapshots, gcpFilter, func(snapshot *computepb.Snapshot) gcpSnapshot {
+		return gcpSnapshot{snapshot: snapshot}
+	})
+}
@@ -0,0 +1,122 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type snapshotsArray []*computepb.Snapshot
+
+func (s snapshotsArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, snapshot := range s {
+		sb.WriteString(snapshot.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterSnapshots(t *testing.T) {
+	snapshots := snapshotsArray{
+		{
+			AutoCreated:                  toBoolPtr(true),
+			CreationTimestamp:            toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			DiskSizeGb:                   toInt64Ptr(100),
+			Id:                           toUint64Ptr(2123462547295123412),
+			Kind:                         toStringPtr("compute#snapshot"),
+			Name:                         toStringPtr("db-daily-20231024"),
+			SnapshotType:                 toStringPtr("STANDARD"),
+			SourceDisk:                   toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/europe-west1-b/disks/db"),
+			SourceSnapshotSchedulePolicy: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/resourcePolicies/daily"),
+			Status:                       toStringPtr("READY"),
+			StorageBytes:                 toInt64Ptr(52613349376),
+			StorageLocations:             []string{"europe-west1"},
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			DiskSizeGb:        toInt64Ptr(10),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#snapshot"),
+			Name:              toStringPtr("web-manual"),
+			SnapshotType:      toStringPtr("ARCHIVE"),
+			SourceDisk:        toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/zones/us-east1-b/disks/web"),
+			Status:            toStringPtr("READY"),
+			StorageBytes:      toInt64Ptr(1073741824),
+			StorageLocations:  []string{"us"},
+			Labels: map[string]string{
+				"keep": "forever",
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantSnapshots snapshotsArray
+		wantErr       bool
+	}{
+		{
+			name: "Retention",
+			args: args{
+				gcpFilter: `creationTimestamp<"2023-11-01T00:00:00Z" AND autoCreated=true -labels.keep:*`,
+			},
+			wantSnapshots: snapshotsArray{
+				snapshots[0],
+			},
+		},
+		{
+			name: "Storage bytes and source disk",
+			args: args{
+				gcpFilter: `storageBytes<1e10 sourceDisk:*/disks/web storageLocations:(us eu)`,
+			},
+			wantSnapshots: snapshotsArray{
+				snapshots[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `family:foo`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSnapshots, err := FilterSnapshots(snapshots, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSnapshots() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotSnapshotsArray := snapshotsArray(gotSnapshots)
+			if !reflect.DeepEqual(gotSnapshotsArray, tt.wantSnapshots) {
+				t.Errorf("FilterSnapshots(): \"%v\". want: \"%v\"", gotSnapshotsArray, tt.wantSnapshots)
+			}
+			t.Log(gotSnapshotsArray)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+
+	"google.golang.org/api/sqladmin/v1"
+)
+
+type gcpSQLInstance struct {
+	instance *sqladmin.DatabaseInstance
+}
+
+func (g gcpSQLInstance) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "createTime":
+		return t.evaluateTimestampString(g.instance.CreateTime)
+	case "ipAddresses":
+		const ipAddressKey = "ipAddress"
+		if ipAddressKey == t.AttributeKey {
+			// e.g. ipAddresses.ipAddress:10.0.0.0/8
+			return filterRepeated(t, g.instance.IpAddresses, func(t term, ipMapping *sqladmin.IpMapping) (bool, error) {
+				return t.evaluateIP(ipMapping.IpAddress)
+			})
+		}
+	}
+	return filterField(t, reflect.ValueOf(g.instance))
+}
+
+// FilterSQLInstances filters the given Cloud SQL instances according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/sql/instances/list
+//  2. The keys are the ones of https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1/instances#DatabaseInstance
+func FilterSQLInstances(instances []*sqladmin.DatabaseInstance, gcpFilter string) ([]*sqladmin.DatabaseInstance, error) {
+	return filterResources(instances, gcpFilter, func(instance *sqladmin.DatabaseInstance) gcpSQLInstance {
+		return gcpSQLInstance{instance: instance}
+	})
+}
@@ -0,0 +1,171 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sqladmin/v1"
+)
+
+type sqlInstancesArray []*sqladmin.DatabaseInstance
+
+func (s sqlInstancesArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, instance := range s {
+		sb.WriteString(instance.Name + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterSQLInstances(t *testing.T) {
+	instances := sqlInstancesArray{
+		{
+			Name:            "orders-pg",
+			DatabaseVersion: "POSTGRES_15",
+			State:           "RUNNABLE",
+			Region:          "europe-west1",
+			CreateTime:      "2023-10-24T09:06:40.108Z",
+			IpAddresses: []*sqladmin.IpMapping{
+				{
+					IpAddress: "34.76.10.2",
+					Type:      "PRIMARY",
+				},
+				{
+					IpAddress: "10.20.0.3",
+					Type:      "PRIVATE",
+				},
+			},
+			Settings: &sqladmin.Settings{
+				Tier:             "db-custom-4-16384",
+				AvailabilityType: "REGIONAL",
+				DataDiskSizeGb:   500,
+				IpConfiguration: &sqladmin.IpConfiguration{
+					Ipv4Enabled: true,
+					AuthorizedNetworks: []*sqladmin.AclEntry{
+						{
+							Name:  "office",
+							Value: "203.0.113.0/24",
+						},
+					},
+				},
+				BackupConfiguration: &sqladmin.BackupConfiguration{
+					Enabled: true,
+				},
+				UserLabels: map[string]string{
+					"team": "orders",
+				},
+			},
+		},
+		{
+			Name:            "legacy-mysql",
+			DatabaseVersion: "MYSQL_5_7",
+			State:           "RUNNABLE",
+			Region:          "us-central1",
+			CreateTime:      "2019-02-01T12:00:00Z",
+			Settings: &sqladmin.Settings{
+				Tier:             "db-n1-standard-1",
+				AvailabilityType: "ZONAL",
+				DataDiskSizeGb:   20,
+				IpConfiguration: &sqladmin.IpConfiguration{
+					PrivateNetwork: "projects/appgate-dev/global/networks/default",
+				},
+			},
+		},
+		{
+			Name:            "reporting-pg",
+			DatabaseVersion: "POSTGRES_13",
+			State:           "SUSPENDED",
+			Region:          "europe-west3",
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantSQLInstances sqlInstancesArray
+		wantErr          bool
+	}{
+		{
+			name: "Nested settings",
+			args: args{
+				gcpFilter: `databaseVersion:POSTGRES_* settings.tier:db-custom-* settings.ipConfiguration.ipv4Enabled=true state=RUNNABLE region:europe-*`,
+			},
+			wantSQLInstances: sqlInstancesArray{
+				instances[0],
+			},
+		},
+		{
+			name: "Unset settings",
+			args: args{
+				gcpFilter: `NOT settings.backupConfiguration.enabled=true AND NOT settings.userLabels:*`,
+			},
+			wantSQLInstances: sqlInstancesArray{
+				instances[1],
+				instances[2],
+			},
+		},
+		{
+			name: "User labels, numbers and repeated settings",
+			args: args{
+				gcpFilter: `settings.userLabels.team=orders AND settings.dataDiskSizeGb>100 AND settings.ipConfiguration.authorizedNetworks.value:203.0.113.0/24`,
+			},
+			wantSQLInstances: sqlInstancesArray{
+				instances[0],
+			},
+		},
+		{
+			name: "IP addresses and create time",
+			args: args{
+				gcpFilter: `ipAddresses.ipAddress:10.0.0.0/8 OR createTime<"2020-01-01T00:00:00Z"`,
+			},
+			wantSQLInstances: sqlInstancesArray{
+				instances[0],
+				instances[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `settings.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQLInstances, err := FilterSQLInstances(instances, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSQLInstances() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotSQLInstancesArray := sqlInstancesArray(gotSQLInstances)
+			if !reflect.DeepEqual(gotSQLInstancesArray, tt.wantSQLInstances) {
+				t.Errorf("FilterSQLInstances(): \"%v\". want: \"%v\"", gotSQLInstancesArray, tt.wantSQLInstances)
+			}
+			t.Log(gotSQLInstancesArray)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpSubnetwork struct {
+	subnetwork *computepb.Subnetwork
+}
+
+func (g gcpSubnetwork) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluate(g.subnetwork.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.subnetwork.GetDescription())
+	case "enableFlowLogs":
+		return t.evaluate(strconv.FormatBool(g.subnetwork.GetEnableFlowLogs()))
+	case "externalIpv6Prefix":
+		return t.evaluate(g.subnetwork.GetExternalIpv6Prefix())
+	case "fingerprint":
+		return t.evaluate(g.subnetwork.GetFingerprint())
+	case "gatewayAddress":
+		return t.evaluate(g.subnetwork.GetGatewayAddress())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.subnetwork.GetId(), 10))
+	case "internalIpv6Prefix":
+		return t.evaluate(g.subnetwork.GetInternalIpv6Prefix())
+	case "ipCidrRange":
+		return t.evaluate(g.subnetwork.GetIpCidrRange())
+	case "ipv6AccessType":
+		return t.evaluate(g.subnetwork.GetIpv6AccessType())
+	case "ipv6CidrRange":
+		return t.evaluate(g.subnetwork.GetIpv6CidrRange())
+	case "kind":
+		return t.evaluate(g.subnetwork.GetKind())
+	case "logConfig":
+		var logConfigValue string
+		switch t.AttributeKey {
+		case "enable":
+			logConfigValue = strconv.FormatBool(g.subnetwork.GetLogConfig().GetEnable())
+		case "aggregationInterval":
+			logConfigValue = g.subnetwork.GetLogConfig().GetAggregationInterval()
+		case "flowSampling":
+			logConfigValue = fmt.Sprint(g.subnetwork.GetLogConfig().GetFlowSampling())
+		case "metadata":
+			logConfigValue = g.subnetwork.GetLogConfig().GetMetadata()
+		default:
+			return false, fmt.Errorf("unknown logConfig key %v", t.AttributeKey)
+		}
+		return t.evaluate(logConfigValue)
+	case "name":
+		return t.evaluate(g.subnetwork.GetName())
+	case "network":
+		return t.evaluate(g.subnetwork.GetNetwork())
+	case "privateIpGoogleAccess":
+		return t.evaluate(strconv.FormatBool(g.subnetwork.GetPrivateIpGoogleAccess()))
+	case "privateIpv6GoogleAccess":
+		return t.evaluate(g.subnetwork.GetPrivateIpv6GoogleAccess())
+	case "purpose":
+		return t.evaluate(g.subnetwork.GetPurpose())
+	case "region":
+		return t.evaluate(g.subnetwork.GetRegion())
+	case "role":
+		return t.evaluate(g.subnetwork.GetRole())
+	case "secondaryIpRanges":
+		// e.g. secondaryIpRanges.rangeName:pods, secondaryIpRanges.ipCidrRange:10.4.*
+		return filterRepeated(t, g.subnetwork.GetSecondaryIpRanges(), filterSecondaryIpRange)
+	case "selfLink":
+		return t.evaluate(g.subnetwork.GetSelfLink())
+	case "stackType":
+		return t.evaluate(g.subnetwork.GetStackType())
+	case "state":
+		return t.evaluate(g.subnetwork.GetState())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterSecondaryIpRange(t term, secondaryIpRange *computepb.SubnetworkSecondaryRange) (bool, error) {
+	switch t.Key {
+	case "ipCidrRange":
+		return t.evaluate(secondaryIpRange.GetIpCidrRange())
+	case "rangeName":
+		return t.evaluate(secondaryIpRange.GetRangeName())
+	case "reservedInternalRange":
+		return t.evaluate(secondaryIpRange.GetReservedInternalRange())
+	default:
+		return false, fmt.Errorf("unknown secondaryIpRanges key %v", t.Key)
+	}
+}
+
+// FilterSubnetworks filters the given subnetworks according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/aggregatedList
+func FilterSubnetworks(subnetworks []*computepb.Subnetwork, gcpFilter string) ([]*computepb.Subnetwork, error) {
+	return filterResources(subnetworks, gcpFilter, func(subnetwork *computepb.Subnetwork) gcpSubnetwork {
+		return gcpSubnetwork{subnetwork: subnetwork}
+	})
+}
@@ -0,0 +1,155 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type subnetworksArray []*computepb.Subnetwork
+
+func (s subnetworksArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, subnetwork := range s {
+		sb.WriteString(subnetwork.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterSubnetworks(t *testing.T) {
+	subnetworks := subnetworksArray{
+		{
+			CreationTimestamp:     toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			GatewayAddress:        toStringPtr("10.132.0.1"),
+			Id:                    toUint64Ptr(3123462547295123412),
+			IpCidrRange:           toStringPtr("10.132.0.0/20"),
+			Kind:                  toStringPtr("compute#subnetwork"),
+			Name:                  toStringPtr("default"),
+			Network:               toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/default"),
+			PrivateIpGoogleAccess: toBoolPtr(false),
+			Purpose:               toStringPtr("PRIVATE"),
+			Region:                toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			StackType:             toStringPtr("IPV4_ONLY"),
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			EnableFlowLogs:    toBoolPtr(true),
+			GatewayAddress:    toStringPtr("10.0.0.1"),
+			Id:                toUint64Ptr(5123462547295123412),
+			IpCidrRange:       toStringPtr("10.0.0.0/24"),
+			Kind:              toStringPtr("compute#subnetwork"),
+			LogConfig: &computepb.SubnetworkLogConfig{
+				Enable:              toBoolPtr(true),
+				AggregationInterval: toStringPtr("INTERVAL_5_SEC"),
+			},
+			Name:                  toStringPtr("gke-nodes"),
+			Network:               toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			PrivateIpGoogleAccess: toBoolPtr(true),
+			Purpose:               toStringPtr("PRIVATE"),
+			Region:                toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			SecondaryIpRanges: []*computepb.SubnetworkSecondaryRange{
+				{
+					RangeName:   toStringPtr("pods"),
+					IpCidrRange: toStringPtr("10.4.0.0/14"),
+				},
+				{
+					RangeName:   toStringPtr("services"),
+					IpCidrRange: toStringPtr("10.8.0.0/20"),
+				},
+			},
+			StackType: toStringPtr("IPV4_IPV6"),
+		},
+		{
+			CreationTimestamp: toStringPtr("2024-02-11T01:12:21.102-08:00"),
+			Id:                toUint64Ptr(7123462547295123412),
+			IpCidrRange:       toStringPtr("10.129.0.0/23"),
+			Kind:              toStringPtr("compute#subnetwork"),
+			Name:              toStringPtr("proxy-only"),
+			Network:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/networks/shared-vpc"),
+			Purpose:           toStringPtr("REGIONAL_MANAGED_PROXY"),
+			Region:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-east1"),
+			Role:              toStringPtr("ACTIVE"),
+			StackType:         toStringPtr("IPV4_ONLY"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantSubnetworks subnetworksArray
+		wantErr         bool
+	}{
+		{
+			name: "Private Google access disabled",
+			args: args{
+				gcpFilter: `privateIpGoogleAccess=false purpose=PRIVATE`,
+			},
+			wantSubnetworks: subnetworksArray{
+				subnetworks[0],
+			},
+		},
+		{
+			name: "Secondary IP ranges",
+			args: args{
+				gcpFilter: `secondaryIpRanges.rangeName:(pods "foo") AND secondaryIpRanges.ipCidrRange:10.8.* logConfig.enable=true`,
+			},
+			wantSubnetworks: subnetworksArray{
+				subnetworks[1],
+			},
+		},
+		{
+			name: "Stack type, role, region",
+			args: args{
+				gcpFilter: `stackType=IPV4_ONLY (role:* OR -secondaryIpRanges:*) region ~ ".*/us-east1$"`,
+			},
+			wantSubnetworks: subnetworksArray{
+				subnetworks[2],
+			},
+		},
+		{
+			name: "Unknown secondaryIpRanges key",
+			args: args{
+				gcpFilter: `secondaryIpRanges.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSubnetworks, err := FilterSubnetworks(subnetworks, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSubnetworks() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotSubnetworksArray := subnetworksArray(gotSubnetworks)
+			if !reflect.DeepEqual(gotSubnetworksArray, tt.wantSubnetworks) {
+				t.Errorf("FilterSubnetworks(): \"%v\". want: \"%v\"", gotSubnetworksArray, tt.wantSubnetworks)
+			}
+			t.Log(gotSubnetworksArray)
+		})
+	}
+}
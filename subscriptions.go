@@ -0,0 +1,142 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+)
+
+type gcpSubscription struct {
+	subscription *pubsubpb.Subscription
+}
+
+func (g gcpSubscription) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "ackDeadlineSeconds":
+		return t.evaluate(strconv.FormatInt(int64(g.subscription.GetAckDeadlineSeconds()), 10))
+	case "bigqueryConfig":
+		bigqueryConfig := g.subscription.GetBigqueryConfig()
+		switch t.AttributeKey {
+		case "serviceAccountEmail":
+			return t.evaluate(bigqueryConfig.GetServiceAccountEmail())
+		case "state":
+			return t.evaluate(bigqueryConfig.GetState().String())
+		case "table":
+			return t.evaluate(bigqueryConfig.GetTable())
+		default:
+			return false, fmt.Errorf("unknown bigqueryConfig key %v", t.AttributeKey)
+		}
+	case "cloudStorageConfig":
+		cloudStorageConfig := g.subscription.GetCloudStorageConfig()
+		switch t.AttributeKey {
+		case "bucket":
+			return t.evaluate(cloudStorageConfig.GetBucket())
+		case "maxDuration":
+			return t.evaluateProtoDuration(cloudStorageConfig.GetMaxDuration())
+		case "serviceAccountEmail":
+			return t.evaluate(cloudStorageConfig.GetServiceAccountEmail())
+		case "state":
+			return t.evaluate(cloudStorageConfig.GetState().String())
+		default:
+			return false, fmt.Errorf("unknown cloudStorageConfig key %v", t.AttributeKey)
+		}
+	case "deadLetterPolicy":
+		deadLetterPolicy := g.subscription.GetDeadLetterPolicy()
+		switch t.AttributeKey {
+		case "deadLetterTopic":
+			return t.evaluate(deadLetterPolicy.GetDeadLetterTopic())
+		case "maxDeliveryAttempts":
+			return t.evaluate(strconv.FormatInt(int64(deadLetterPolicy.GetMaxDeliveryAttempts()), 10))
+		default:
+			return false, fmt.Errorf("unknown deadLetterPolicy key %v", t.AttributeKey)
+		}
+	case "detached":
+		return t.evaluate(strconv.FormatBool(g.subscription.GetDetached()))
+	case "enableExactlyOnceDelivery":
+		return t.evaluate(strconv.FormatBool(g.subscription.GetEnableExactlyOnceDelivery()))
+	case "enableMessageOrdering":
+		return t.evaluate(strconv.FormatBool(g.subscription.GetEnableMessageOrdering()))
+	case "expirationPolicy":
+		const ttlKey = "ttl"
+		if ttlKey == t.AttributeKey {
+			return t.evaluateProtoDuration(g.subscription.GetExpirationPolicy().GetTtl())
+		}
+		return false, fmt.Errorf("unknown expirationPolicy key %v", t.AttributeKey)
+	case "filter":
+		return t.evaluate(g.subscription.GetFilter())
+	case "labels":
+		return t.evaluateLabels(g.subscription.GetLabels())
+	case "messageRetentionDuration":
+		return t.evaluateProtoDuration(g.subscription.GetMessageRetentionDuration())
+	case "name":
+		return t.evaluate(g.subscription.GetName())
+	case "pushConfig":
+		pushConfig := g.subscription.GetPushConfig()
+		pushConfigTerm := t.descend()
+		switch pushConfigTerm.Key {
+		case "attributes":
+			return pushConfigTerm.evaluateLabels(pushConfig.GetAttributes())
+		case "oidcToken":
+			oidcToken := pushConfig.GetOidcToken()
+			switch pushConfigTerm.AttributeKey {
+			case "audience":
+				return pushConfigTerm.evaluate(oidcToken.GetAudience())
+			case "serviceAccountEmail":
+				return pushConfigTerm.evaluate(oidcToken.GetServiceAccountEmail())
+			default:
+				return false, fmt.Errorf("unknown oidcToken key %v", pushConfigTerm.AttributeKey)
+			}
+		case "pushEndpoint":
+			return pushConfigTerm.evaluate(pushConfig.GetPushEndpoint())
+		default:
+			return false, fmt.Errorf("unknown pushConfig key %v", pushConfigTerm.Key)
+		}
+	case "retainAckedMessages":
+		return t.evaluate(strconv.FormatBool(g.subscription.GetRetainAckedMessages()))
+	case "retryPolicy":
+		retryPolicy := g.subscription.GetRetryPolicy()
+		switch t.AttributeKey {
+		case "maximumBackoff":
+			return t.evaluateProtoDuration(retryPolicy.GetMaximumBackoff())
+		case "minimumBackoff":
+			return t.evaluateProtoDuration(retryPolicy.GetMinimumBackoff())
+		default:
+			return false, fmt.Errorf("unknown retryPolicy key %v", t.AttributeKey)
+		}
+	case "state":
+		return t.evaluate(g.subscription.GetState().String())
+	case "topic":
+		return t.evaluate(g.subscription.GetTopic())
+	case "topicMessageRetentionDuration":
+		return t.evaluateProtoDuration(g.subscription.GetTopicMessageRetentionDuration())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterSubscriptions filters the given Pub/Sub subscriptions according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/pubsub/subscriptions/list
+//  2. The durations e.g. messageRetentionDuration are compared with ISO 8601 durations e.g. P7D or seconds
+func FilterSubscriptions(subscriptions []*pubsubpb.Subscription, gcpFilter string) ([]*pubsubpb.Subscription, error) {
+	return filterResources(subscriptions, gcpFilter, func(subscription *pubsubpb.Subscription) gcpSubscription {
+		return gcpSubscription{subscription: subscription}
+	})
+}
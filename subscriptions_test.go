@@ -0,0 +1,169 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type subscriptionsArray []*pubsubpb.Subscription
+
+func (s subscriptionsArray) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, subscription := range s {
+		sb.WriteString(subscription.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterSubscriptions(t *testing.T) {
+	subscriptions := subscriptionsArray{
+		{
+			Name:                     "projects/appgate-dev/subscriptions/orders-push",
+			Topic:                    "projects/appgate-dev/topics/orders",
+			AckDeadlineSeconds:       60,
+			MessageRetentionDuration: durationpb.New(7 * 24 * time.Hour),
+			PushConfig: &pubsubpb.PushConfig{
+				PushEndpoint: "https://orders-abcdefghij-ew.a.run.app/push",
+			},
+			DeadLetterPolicy: &pubsubpb.DeadLetterPolicy{
+				DeadLetterTopic:     "projects/appgate-dev/topics/orders-dead-letter",
+				MaxDeliveryAttempts: 5,
+			},
+			RetryPolicy: &pubsubpb.RetryPolicy{
+				MinimumBackoff: durationpb.New(10 * time.Second),
+				MaximumBackoff: durationpb.New(10 * time.Minute),
+			},
+		},
+		{
+			Name:                     "projects/appgate-dev/subscriptions/audit-pull",
+			Topic:                    "projects/appgate-dev/topics/audit",
+			AckDeadlineSeconds:       10,
+			MessageRetentionDuration: durationpb.New(10 * time.Minute),
+			EnableMessageOrdering:    true,
+			Filter:                   `attributes.severity = "HIGH"`,
+			ExpirationPolicy: &pubsubpb.ExpirationPolicy{
+				Ttl: durationpb.New(31 * 24 * time.Hour),
+			},
+			Labels: map[string]string{
+				"team": "security",
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantSubscriptions subscriptionsArray
+		wantErr           bool
+	}{
+		{
+			name: "Push subscriptions with dead lettering",
+			args: args{
+				gcpFilter: `pushConfig.pushEndpoint:* AND deadLetterPolicy.deadLetterTopic:*dead-letter AND deadLetterPolicy.maxDeliveryAttempts<=5`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[0],
+			},
+		},
+		{
+			name: "Ack deadline and retention",
+			args: args{
+				gcpFilter: `ackDeadlineSeconds<30 AND messageRetentionDuration<P1D`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[1],
+			},
+		},
+		{
+			name: "Ordering, filter and expiration",
+			args: args{
+				gcpFilter: `enableMessageOrdering=true AND filter:* AND expirationPolicy.ttl>P4W AND labels.team=security`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[1],
+			},
+		},
+		{
+			name: "Retry policy",
+			args: args{
+				gcpFilter: `retryPolicy.minimumBackoff>=PT10S AND retryPolicy.maximumBackoff<=600`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[0],
+			},
+		},
+		{
+			name: "gcloud durations",
+			args: args{
+				gcpFilter: `messageRetentionDuration>=7d AND retryPolicy.maximumBackoff=10m AND retryPolicy.minimumBackoff<1m30s`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[0],
+			},
+		},
+		{
+			name: "Durations not equal",
+			args: args{
+				gcpFilter: `messageRetentionDuration!=P7D`,
+			},
+			wantSubscriptions: subscriptionsArray{
+				subscriptions[1],
+			},
+		},
+		{
+			name: "Durations with regular expressions",
+			args: args{
+				gcpFilter: `messageRetentionDuration~P7D`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown push config key",
+			args: args{
+				gcpFilter: `pushConfig.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSubscriptions, err := FilterSubscriptions(subscriptions, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSubscriptions() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotSubscriptionsArray := subscriptionsArray(gotSubscriptions)
+			if !reflect.DeepEqual(gotSubscriptionsArray, tt.wantSubscriptions) {
+				t.Errorf("FilterSubscriptions(): \"%v\". want: \"%v\"", gotSubscriptionsArray, tt.wantSubscriptions)
+			}
+			t.Log(gotSubscriptionsArray)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+
+	"google.golang.org/api/bigquery/v2"
+)
+
+type gcpTable struct {
+	table *bigquery.Table
+}
+
+func (g gcpTable) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTime":
+		return t.evaluateEpochMilliseconds(g.table.CreationTime, forceSent(g.table.CreationTime, "CreationTime", g.table.ForceSendFields))
+	case "expirationTime":
+		return t.evaluateEpochMilliseconds(g.table.ExpirationTime, forceSent(g.table.ExpirationTime, "ExpirationTime", g.table.ForceSendFields))
+	case "lastModifiedTime":
+		return t.evaluateEpochMilliseconds(int64(g.table.LastModifiedTime), forceSent(g.table.LastModifiedTime, "LastModifiedTime", g.table.ForceSendFields))
+	case "streamingBuffer":
+		const oldestEntryTimeKey = "oldestEntryTime"
+		if oldestEntryTimeKey == t.AttributeKey {
+			streamingBuffer := g.table.StreamingBuffer
+			if streamingBuffer == nil {
+				return t.evaluateUnset()
+			}
+			return t.evaluateEpochMilliseconds(int64(streamingBuffer.OldestEntryTime), forceSent(streamingBuffer.OldestEntryTime, "OldestEntryTime", streamingBuffer.ForceSendFields))
+		}
+	}
+	return filterField(t, reflect.ValueOf(g.table))
+}
+
+// FilterTables filters the given BigQuery tables according to the gcpFilter
+// Notes:
+//  1. The keys are the ones of https://cloud.google.com/bigquery/docs/reference/rest/v2/tables#Table
+//  2. The times in milliseconds since the epoch e.g. creationTime are compared with RFC3339 times
+//     e.g. expirationTime<"2024-01-01T00:00:00Z" or milliseconds e.g. expirationTime<1704067200000
+func FilterTables(tables []*bigquery.Table, gcpFilter string) ([]*bigquery.Table, error) {
+	return filterResources(tables, gcpFilter, func(table *bigquery.Table) gcpTable {
+		return gcpTable{table: table}
+	})
+}
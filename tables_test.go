@@ -0,0 +1,158 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/bigquery/v2"
+)
+
+type tablesArray []*bigquery.Table
+
+func (t tablesArray) String() string {
+	if len(t) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, table := range t {
+		sb.WriteString(table.Id + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterTables(t *testing.T) {
+	tables := tablesArray{
+		{
+			Id:             "appgate-dev:raw_events.clicks",
+			Type:           "TABLE",
+			NumBytes:       2500000000000,
+			NumRows:        18000000000,
+			CreationTime:   1698138400108,
+			ExpirationTime: 1735689600000,
+			TimePartitioning: &bigquery.TimePartitioning{
+				Type:  "DAY",
+				Field: "event_time",
+			},
+			StreamingBuffer: &bigquery.Streamingbuffer{
+				OldestEntryTime: 1709294400000,
+			},
+			Labels: map[string]string{
+				"pii": "false",
+			},
+		},
+		{
+			Id:           "appgate-dev:customers.active",
+			Type:         "VIEW",
+			CreationTime: 1709294400000,
+			View: &bigquery.ViewDefinition{
+				Query:        "SELECT * FROM customers.all WHERE active",
+				UseLegacySql: false,
+			},
+			Labels: map[string]string{
+				"pii": "true",
+			},
+		},
+		{
+			Id:              "appgate-dev:scratch.tmp",
+			Type:            "TABLE",
+			CreationTime:    1709294400000,
+			ExpirationTime:  0,
+			ForceSendFields: []string{"ExpirationTime"},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantTables tablesArray
+		wantErr    bool
+	}{
+		{
+			name: "Large tables",
+			args: args{
+				gcpFilter: `type=TABLE numBytes>1e12 numRows>=1.8e10`,
+			},
+			wantTables: tablesArray{
+				tables[0],
+			},
+		},
+		{
+			name: "Views",
+			args: args{
+				gcpFilter: `type=VIEW AND view.query:*customers* AND labels.pii:true AND NOT expirationTime:*`,
+			},
+			wantTables: tablesArray{
+				tables[1],
+			},
+		},
+		{
+			name: "Expiration and streaming buffer times",
+			args: args{
+				gcpFilter: `expirationTime<"2025-01-02T00:00:00Z" AND streamingBuffer.oldestEntryTime>="2024-03-01T12:00:00Z" AND timePartitioning.type=DAY`,
+			},
+			wantTables: tablesArray{
+				tables[0],
+			},
+		},
+		{
+			name: "Creation time in milliseconds",
+			args: args{
+				gcpFilter: `creationTime>1700000000000`,
+			},
+			wantTables: tablesArray{
+				tables[1],
+				tables[2],
+			},
+		},
+		{
+			name: "Zero expiration time",
+			args: args{
+				gcpFilter: `expirationTime=0 AND expirationTime<1 AND expirationTime<"1970-01-01T00:00:01Z"`,
+			},
+			wantTables: tablesArray{
+				tables[2],
+			},
+		},
+		{
+			name: "Invalid time",
+			args: args{
+				gcpFilter: `creationTime>yesterday`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTables, err := FilterTables(tables, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterTables() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotTablesArray := tablesArray(gotTables)
+			if !reflect.DeepEqual(gotTablesArray, tt.wantTables) {
+				t.Errorf("FilterTables(): \"%v\". want: \"%v\"", gotTablesArray, tt.wantTables)
+			}
+			t.Log(gotTablesArray)
+		})
+	}
+}
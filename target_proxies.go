@@ -0,0 +1,160 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpTargetHttpProxy struct {
+	targetHttpProxy *computepb.TargetHttpProxy
+}
+
+func (g gcpTargetHttpProxy) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.targetHttpProxy.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.targetHttpProxy.GetDescription())
+	case "fingerprint":
+		return t.evaluate(g.targetHttpProxy.GetFingerprint())
+	case "httpKeepAliveTimeoutSec":
+		return t.evaluate(strconv.FormatInt(int64(g.targetHttpProxy.GetHttpKeepAliveTimeoutSec()), 10))
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.targetHttpProxy.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.targetHttpProxy.GetKind())
+	case "name":
+		return t.evaluate(g.targetHttpProxy.GetName())
+	case "proxyBind":
+		return t.evaluate(strconv.FormatBool(g.targetHttpProxy.GetProxyBind()))
+	case "region":
+		return t.evaluate(g.targetHttpProxy.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.targetHttpProxy.GetSelfLink())
+	case "urlMap":
+		return t.evaluate(g.targetHttpProxy.GetUrlMap())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterTargetHttpProxies filters the given target HTTP proxies according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpProxies/aggregatedList
+func FilterTargetHttpProxies(targetHttpProxies []*computepb.TargetHttpProxy, gcpFilter string) ([]*computepb.TargetHttpProxy, error) {
+	return filterResources(targetHttpProxies, gcpFilter, func(targetHttpProxy *computepb.TargetHttpProxy) gcpTargetHttpProxy {
+		return gcpTargetHttpProxy{targetHttpProxy: targetHttpProxy}
+	})
+}
+
+type gcpTargetHttpsProxy struct {
+	targetHttpsProxy *computepb.TargetHttpsProxy
+}
+
+func (g gcpTargetHttpsProxy) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "authorizationPolicy":
+		return t.evaluate(g.targetHttpsProxy.GetAuthorizationPolicy())
+	case "certificateMap":
+		return t.evaluate(g.targetHttpsProxy.GetCertificateMap())
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.targetHttpsProxy.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.targetHttpsProxy.GetDescription())
+	case "fingerprint":
+		return t.evaluate(g.targetHttpsProxy.GetFingerprint())
+	case "httpKeepAliveTimeoutSec":
+		return t.evaluate(strconv.FormatInt(int64(g.targetHttpsProxy.GetHttpKeepAliveTimeoutSec()), 10))
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.targetHttpsProxy.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.targetHttpsProxy.GetKind())
+	case "name":
+		return t.evaluate(g.targetHttpsProxy.GetName())
+	case "proxyBind":
+		return t.evaluate(strconv.FormatBool(g.targetHttpsProxy.GetProxyBind()))
+	case "quicOverride":
+		return t.evaluate(g.targetHttpsProxy.GetQuicOverride())
+	case "region":
+		return t.evaluate(g.targetHttpsProxy.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.targetHttpsProxy.GetSelfLink())
+	case "serverTlsPolicy":
+		return t.evaluate(g.targetHttpsProxy.GetServerTlsPolicy())
+	case "sslCertificates":
+		return t.evaluateRepeated(g.targetHttpsProxy.GetSslCertificates())
+	case "sslPolicy":
+		return t.evaluate(g.targetHttpsProxy.GetSslPolicy())
+	case "urlMap":
+		return t.evaluate(g.targetHttpsProxy.GetUrlMap())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterTargetHttpsProxies filters the given target HTTPS proxies according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies/aggregatedList
+func FilterTargetHttpsProxies(targetHttpsProxies []*computepb.TargetHttpsProxy, gcpFilter string) ([]*computepb.TargetHttpsProxy, error) {
+	return filterResources(targetHttpsProxies, gcpFilter, func(targetHttpsProxy *computepb.TargetHttpsProxy) gcpTargetHttpsProxy {
+		return gcpTargetHttpsProxy{targetHttpsProxy: targetHttpsProxy}
+	})
+}
+
+type gcpTargetTcpProxy struct {
+	targetTcpProxy *computepb.TargetTcpProxy
+}
+
+func (g gcpTargetTcpProxy) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.targetTcpProxy.GetCreationTimestamp())
+	case "description":
+		return t.evaluate(g.targetTcpProxy.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.targetTcpProxy.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.targetTcpProxy.GetKind())
+	case "name":
+		return t.evaluate(g.targetTcpProxy.GetName())
+	case "proxyBind":
+		return t.evaluate(strconv.FormatBool(g.targetTcpProxy.GetProxyBind()))
+	case "proxyHeader":
+		return t.evaluate(g.targetTcpProxy.GetProxyHeader())
+	case "region":
+		return t.evaluate(g.targetTcpProxy.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.targetTcpProxy.GetSelfLink())
+	case "service":
+		return t.evaluate(g.targetTcpProxy.GetService())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterTargetTcpProxies filters the given target TCP proxies according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/targetTcpProxies/aggregatedList
+func FilterTargetTcpProxies(targetTcpProxies []*computepb.TargetTcpProxy, gcpFilter string) ([]*computepb.TargetTcpProxy, error) {
+	return filterResources(targetTcpProxies, gcpFilter, func(targetTcpProxy *computepb.TargetTcpProxy) gcpTargetTcpProxy {
+		return gcpTargetTcpProxy{targetTcpProxy: targetTcpProxy}
+	})
+}
@@ -0,0 +1,269 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type targetHttpProxiesArray []*computepb.TargetHttpProxy
+
+func (t targetHttpProxiesArray) String() string {
+	if len(t) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, targetHttpProxy := range t {
+		sb.WriteString(targetHttpProxy.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+type targetHttpsProxiesArray []*computepb.TargetHttpsProxy
+
+func (t targetHttpsProxiesArray) String() string {
+	if len(t) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, targetHttpsProxy := range t {
+		sb.WriteString(targetHttpsProxy.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+type targetTcpProxiesArray []*computepb.TargetTcpProxy
+
+func (t targetTcpProxiesArray) String() string {
+	if len(t) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, targetTcpProxy := range t {
+		sb.WriteString(targetTcpProxy.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterTargetHttpProxies(t *testing.T) {
+	targetHttpProxies := targetHttpProxiesArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#targetHttpProxy"),
+			Name:              toStringPtr("testlbhttp-target-proxy"),
+			UrlMap:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/urlMaps/testlbhttp"),
+		},
+		{
+			CreationTimestamp:       toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			HttpKeepAliveTimeoutSec: toInt32Ptr(610),
+			Id:                      toUint64Ptr(4123462547295123412),
+			Kind:                    toStringPtr("compute#targetHttpProxy"),
+			Name:                    toStringPtr("regional-proxy"),
+			Region:                  toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			UrlMap:                  toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1/urlMaps/regional"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		wantTargetHttpProxies targetHttpProxiesArray
+		wantErr               bool
+	}{
+		{
+			name: "Global proxies of a URL map",
+			args: args{
+				gcpFilter: `urlMap:*urlMaps/testlbhttp -region:*`,
+			},
+			wantTargetHttpProxies: targetHttpProxiesArray{
+				targetHttpProxies[0],
+			},
+		},
+		{
+			name: "Keep alive timeout",
+			args: args{
+				gcpFilter: `httpKeepAliveTimeoutSec>600 AND name:regional-*`,
+			},
+			wantTargetHttpProxies: targetHttpProxiesArray{
+				targetHttpProxies[1],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTargetHttpProxies, err := FilterTargetHttpProxies(targetHttpProxies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterTargetHttpProxies() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotTargetHttpProxiesArray := targetHttpProxiesArray(gotTargetHttpProxies)
+			if !reflect.DeepEqual(gotTargetHttpProxiesArray, tt.wantTargetHttpProxies) {
+				t.Errorf("FilterTargetHttpProxies(): \"%v\". want: \"%v\"", gotTargetHttpProxiesArray, tt.wantTargetHttpProxies)
+			}
+			t.Log(gotTargetHttpProxiesArray)
+		})
+	}
+}
+
+func TestFilterTargetHttpsProxies(t *testing.T) {
+	targetHttpsProxies := targetHttpsProxiesArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#targetHttpsProxy"),
+			Name:              toStringPtr("web-https-proxy"),
+			QuicOverride:      toStringPtr("ENABLE"),
+			SslCertificates: []string{
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/global/sslCertificates/web-cert",
+				"https://www.googleapis.com/compute/v1/projects/appgate-dev/global/sslCertificates/web-cert-2024",
+			},
+			SslPolicy: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/sslPolicies/modern"),
+			UrlMap:    toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/urlMaps/web"),
+		},
+		{
+			CertificateMap:    toStringPtr("//certificatemanager.googleapis.com/projects/appgate-dev/locations/global/certificateMaps/api"),
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#targetHttpsProxy"),
+			Name:              toStringPtr("api-https-proxy"),
+			QuicOverride:      toStringPtr("NONE"),
+			UrlMap:            toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/urlMaps/api"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		wantTargetHttpsProxies targetHttpsProxiesArray
+		wantErr                bool
+	}{
+		{
+			name: "SSL certificates and policies",
+			args: args{
+				gcpFilter: `sslCertificates:*web-cert-2024 sslPolicy:*modern quicOverride=ENABLE`,
+			},
+			wantTargetHttpsProxies: targetHttpsProxiesArray{
+				targetHttpsProxies[0],
+			},
+		},
+		{
+			name: "Certificate maps",
+			args: args{
+				gcpFilter: `certificateMap:* AND -sslCertificates:*`,
+			},
+			wantTargetHttpsProxies: targetHttpsProxiesArray{
+				targetHttpsProxies[1],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTargetHttpsProxies, err := FilterTargetHttpsProxies(targetHttpsProxies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterTargetHttpsProxies() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotTargetHttpsProxiesArray := targetHttpsProxiesArray(gotTargetHttpsProxies)
+			if !reflect.DeepEqual(gotTargetHttpsProxiesArray, tt.wantTargetHttpsProxies) {
+				t.Errorf("FilterTargetHttpsProxies(): \"%v\". want: \"%v\"", gotTargetHttpsProxiesArray, tt.wantTargetHttpsProxies)
+			}
+			t.Log(gotTargetHttpsProxiesArray)
+		})
+	}
+}
+
+func TestFilterTargetTcpProxies(t *testing.T) {
+	targetTcpProxies := targetTcpProxiesArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			Id:                toUint64Ptr(2123462547295123412),
+			Kind:              toStringPtr("compute#targetTcpProxy"),
+			Name:              toStringPtr("tcp-proxy"),
+			ProxyHeader:       toStringPtr("PROXY_V1"),
+			Service:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/backendServices/tcp"),
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			Id:                toUint64Ptr(4123462547295123412),
+			Kind:              toStringPtr("compute#targetTcpProxy"),
+			Name:              toStringPtr("db-proxy"),
+			ProxyHeader:       toStringPtr("NONE"),
+			Service:           toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/backendServices/db"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantTargetTcpProxies targetTcpProxiesArray
+		wantErr              bool
+	}{
+		{
+			name: "Proxy header",
+			args: args{
+				gcpFilter: `proxyHeader!=NONE`,
+			},
+			wantTargetTcpProxies: targetTcpProxiesArray{
+				targetTcpProxies[0],
+			},
+		},
+		{
+			name: "Creation timestamp and service",
+			args: args{
+				gcpFilter: `creationTimestamp>="2023-12-01T00:00:00Z" service:*backendServices/db`,
+			},
+			wantTargetTcpProxies: targetTcpProxiesArray{
+				targetTcpProxies[1],
+			},
+		},
+		{
+			name: "Unknown key",
+			args: args{
+				gcpFilter: `urlMap:*`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTargetTcpProxies, err := FilterTargetTcpProxies(targetTcpProxies, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterTargetTcpProxies() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotTargetTcpProxiesArray := targetTcpProxiesArray(gotTargetTcpProxies)
+			if !reflect.DeepEqual(gotTargetTcpProxiesArray, tt.wantTargetTcpProxies) {
+				t.Errorf("FilterTargetTcpProxies(): \"%v\". want: \"%v\"", gotTargetTcpProxiesArray, tt.wantTargetTcpProxies)
+			}
+			t.Log(gotTargetTcpProxiesArray)
+		})
+	}
+}
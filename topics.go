@@ -0,0 +1,73 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+)
+
+type gcpTopic struct {
+	topic *pubsubpb.Topic
+}
+
+func (g gcpTopic) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "kmsKeyName":
+		return t.evaluate(g.topic.GetKmsKeyName())
+	case "labels":
+		return t.evaluateLabels(g.topic.GetLabels())
+	case "messageRetentionDuration":
+		return t.evaluateProtoDuration(g.topic.GetMessageRetentionDuration())
+	case "messageStoragePolicy":
+		const allowedPersistenceRegionsKey = "allowedPersistenceRegions"
+		if allowedPersistenceRegionsKey == t.AttributeKey {
+			return t.evaluateRepeated(g.topic.GetMessageStoragePolicy().GetAllowedPersistenceRegions())
+		}
+		return false, fmt.Errorf("unknown messageStoragePolicy key %v", t.AttributeKey)
+	case "name":
+		return t.evaluate(g.topic.GetName())
+	case "satisfiesPzs":
+		return t.evaluate(strconv.FormatBool(g.topic.GetSatisfiesPzs()))
+	case "schemaSettings":
+		schemaSettings := g.topic.GetSchemaSettings()
+		switch t.AttributeKey {
+		case "encoding":
+			return t.evaluate(schemaSettings.GetEncoding().String())
+		case "schema":
+			return t.evaluate(schemaSettings.GetSchema())
+		default:
+			return false, fmt.Errorf("unknown schemaSettings key %v", t.AttributeKey)
+		}
+	case "state":
+		return t.evaluate(g.topic.GetState().String())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterTopics filters the given Pub/Sub topics according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/sdk/gcloud/reference/pubsub/topics/list
+//  2. messageRetentionDuration is compared with ISO 8601 durations e.g. P7D or seconds
+func FilterTopics(topics []*pubsubpb.Topic, gcpFilter string) ([]*pubsubpb.Topic, error) {
+	return filterResources(topics, gcpFilter, func(topic *pubsubpb.Topic) gcpTopic {
+		return gcpTopic{topic: topic}
+	})
+}
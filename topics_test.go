@@ -0,0 +1,142 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type topicsArray []*pubsubpb.Topic
+
+func (t topicsArray) String() string {
+	if len(t) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, topic := range t {
+		sb.WriteString(topic.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterTopics(t *testing.T) {
+	topics := topicsArray{
+		{
+			Name:                     "projects/appgate-dev/topics/orders",
+			MessageRetentionDuration: durationpb.New(14 * 24 * time.Hour),
+			KmsKeyName:               "projects/appgate-dev/locations/europe-west1/keyRings/pubsub/cryptoKeys/orders",
+			Labels: map[string]string{
+				"team": "orders",
+			},
+			MessageStoragePolicy: &pubsubpb.MessageStoragePolicy{
+				AllowedPersistenceRegions: []string{"europe-west1", "europe-west4"},
+			},
+		},
+		{
+			Name:                     "projects/appgate-dev/topics/audit",
+			MessageRetentionDuration: durationpb.New(10 * time.Minute),
+		},
+		{
+			Name: "projects/appgate-dev/topics/events",
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantTopics topicsArray
+		wantErr    bool
+	}{
+		{
+			name: "Retention longer than a week",
+			args: args{
+				gcpFilter: `messageRetentionDuration>P7D`,
+			},
+			wantTopics: topicsArray{
+				topics[0],
+			},
+		},
+		{
+			name: "Retention in ISO 8601 time and seconds",
+			args: args{
+				gcpFilter: `messageRetentionDuration=PT10M OR messageRetentionDuration>=1209600`,
+			},
+			wantTopics: topicsArray{
+				topics[0],
+				topics[1],
+			},
+		},
+		{
+			name: "Without retention",
+			args: args{
+				gcpFilter: `NOT messageRetentionDuration:* AND NOT kmsKeyName:*`,
+			},
+			wantTopics: topicsArray{
+				topics[2],
+			},
+		},
+		{
+			name: "Unset retention with a negative operator",
+			args: args{
+				gcpFilter: `messageRetentionDuration!=P14D`,
+			},
+			wantTopics: topicsArray{
+				topics[1],
+				topics[2],
+			},
+		},
+		{
+			name: "Storage policy and labels",
+			args: args{
+				gcpFilter: `messageStoragePolicy.allowedPersistenceRegions:europe-west4 AND labels.team=orders`,
+			},
+			wantTopics: topicsArray{
+				topics[0],
+			},
+		},
+		{
+			name: "Invalid duration",
+			args: args{
+				gcpFilter: `messageRetentionDuration>P7X`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTopics, err := FilterTopics(topics, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterTopics() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotTopicsArray := topicsArray(gotTopics)
+			if !reflect.DeepEqual(gotTopicsArray, tt.wantTopics) {
+				t.Errorf("FilterTopics(): \"%v\". want: \"%v\"", gotTopicsArray, tt.wantTopics)
+			}
+			t.Log(gotTopicsArray)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpUrlMap struct {
+	urlMap *computepb.UrlMap
+}
+
+func (g gcpUrlMap) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.urlMap.GetCreationTimestamp())
+	case "defaultService":
+		return t.evaluate(g.urlMap.GetDefaultService())
+	case "defaultUrlRedirect":
+		defaultUrlRedirect := g.urlMap.GetDefaultUrlRedirect()
+		switch t.AttributeKey {
+		case "hostRedirect":
+			return t.evaluate(defaultUrlRedirect.GetHostRedirect())
+		case "httpsRedirect":
+			return t.evaluate(strconv.FormatBool(defaultUrlRedirect.GetHttpsRedirect()))
+		case "pathRedirect":
+			return t.evaluate(defaultUrlRedirect.GetPathRedirect())
+		case "redirectResponseCode":
+			return t.evaluate(defaultUrlRedirect.GetRedirectResponseCode())
+		case "stripQuery":
+			return t.evaluate(strconv.FormatBool(defaultUrlRedirect.GetStripQuery()))
+		default:
+			return false, fmt.Errorf("unknown defaultUrlRedirect key %v", t.AttributeKey)
+		}
+	case "description":
+		return t.evaluate(g.urlMap.GetDescription())
+	case "fingerprint":
+		return t.evaluate(g.urlMap.GetFingerprint())
+	case "hostRules":
+		// e.g. hostRules.hosts:*.example.com, hostRules.pathMatcher=api
+		return filterRepeated(t, g.urlMap.GetHostRules(), filterHostRule)
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.urlMap.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.urlMap.GetKind())
+	case "name":
+		return t.evaluate(g.urlMap.GetName())
+	case "pathMatchers":
+		// e.g. pathMatchers.defaultService:*backendServices/api
+		return filterRepeated(t, g.urlMap.GetPathMatchers(), filterPathMatcher)
+	case "region":
+		return t.evaluate(g.urlMap.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.urlMap.GetSelfLink())
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+func filterHostRule(t term, hostRule *computepb.HostRule) (bool, error) {
+	switch t.Key {
+	case "description":
+		return t.evaluate(hostRule.GetDescription())
+	case "hosts":
+		return t.evaluateRepeated(hostRule.GetHosts())
+	case "pathMatcher":
+		return t.evaluate(hostRule.GetPathMatcher())
+	default:
+		return false, fmt.Errorf("unknown hostRules key %v", t.Key)
+	}
+}
+
+func filterPathMatcher(t term, pathMatcher *computepb.PathMatcher) (bool, error) {
+	switch t.Key {
+	case "defaultService":
+		return t.evaluate(pathMatcher.GetDefaultService())
+	case "description":
+		return t.evaluate(pathMatcher.GetDescription())
+	case "name":
+		return t.evaluate(pathMatcher.GetName())
+	default:
+		return false, fmt.Errorf("unknown pathMatchers key %v", t.Key)
+	}
+}
+
+// FilterUrlMaps filters the given URL maps according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/urlMaps/aggregatedList
+func FilterUrlMaps(urlMaps []*computepb.UrlMap, gcpFilter string) ([]*computepb.UrlMap, error) {
+	return filterResources(urlMaps, gcpFilter, func(urlMap *computepb.UrlMap) gcpUrlMap {
+		return gcpUrlMap{urlMap: urlMap}
+	})
+}
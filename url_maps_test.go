@@ -0,0 +1,131 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type urlMapsArray []*computepb.UrlMap
+
+func (u urlMapsArray) String() string {
+	if len(u) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, urlMap := range u {
+		sb.WriteString(urlMap.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterUrlMaps(t *testing.T) {
+	urlMaps := urlMapsArray{
+		{
+			CreationTimestamp: toStringPtr("2023-10-24T02:06:40.108-07:00"),
+			DefaultService:    toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/backendServices/web-backend-service"),
+			HostRules: []*computepb.HostRule{
+				{
+					Hosts:       []string{"www.example.com", "example.com"},
+					PathMatcher: toStringPtr("web"),
+				},
+				{
+					Hosts:       []string{"api.example.com"},
+					PathMatcher: toStringPtr("api"),
+				},
+			},
+			Id:   toUint64Ptr(2123462547295123412),
+			Kind: toStringPtr("compute#urlMap"),
+			Name: toStringPtr("web"),
+			PathMatchers: []*computepb.PathMatcher{
+				{
+					Name:           toStringPtr("web"),
+					DefaultService: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/backendServices/web-backend-service"),
+				},
+				{
+					Name:           toStringPtr("api"),
+					DefaultService: toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/global/backendServices/api"),
+				},
+			},
+		},
+		{
+			CreationTimestamp: toStringPtr("2023-12-01T03:52:49.415-08:00"),
+			DefaultUrlRedirect: &computepb.HttpRedirectAction{
+				HttpsRedirect:        toBoolPtr(true),
+				RedirectResponseCode: toStringPtr("MOVED_PERMANENTLY_DEFAULT"),
+				StripQuery:           toBoolPtr(false),
+			},
+			Id:   toUint64Ptr(4123462547295123412),
+			Kind: toStringPtr("compute#urlMap"),
+			Name: toStringPtr("web-redirect"),
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantUrlMaps urlMapsArray
+		wantErr     bool
+	}{
+		{
+			name: "Hosts and path matchers",
+			args: args{
+				gcpFilter: `hostRules.hosts:api.example.com pathMatchers.defaultService:*backendServices/api`,
+			},
+			wantUrlMaps: urlMapsArray{
+				urlMaps[0],
+			},
+		},
+		{
+			name: "HTTPS redirects",
+			args: args{
+				gcpFilter: `defaultUrlRedirect.httpsRedirect=true AND -hostRules:* -defaultService:*`,
+			},
+			wantUrlMaps: urlMapsArray{
+				urlMaps[1],
+			},
+		},
+		{
+			name: "Unknown hostRules key",
+			args: args{
+				gcpFilter: `hostRules.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUrlMaps, err := FilterUrlMaps(urlMaps, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterUrlMaps() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotUrlMapsArray := urlMapsArray(gotUrlMaps)
+			if !reflect.DeepEqual(gotUrlMapsArray, tt.wantUrlMaps) {
+				t.Errorf("FilterUrlMaps(): \"%v\". want: \"%v\"", gotUrlMapsArray, tt.wantUrlMaps)
+			}
+			t.Log(gotUrlMapsArray)
+		})
+	}
+}
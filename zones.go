@@ -0,0 +1,66 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type gcpZone struct {
+	zone *computepb.Zone
+}
+
+func (g gcpZone) filterTerm(t term) (bool, error) {
+	switch t.Key {
+	case "availableCpuPlatforms":
+		return t.evaluateRepeated(g.zone.GetAvailableCpuPlatforms())
+	case "creationTimestamp":
+		return t.evaluateTimestampString(g.zone.GetCreationTimestamp())
+	case "deprecated":
+		return filterDeprecationStatus(t, g.zone.GetDeprecated())
+	case "description":
+		return t.evaluate(g.zone.GetDescription())
+	case "id":
+		return t.evaluate(strconv.FormatUint(g.zone.GetId(), 10))
+	case "kind":
+		return t.evaluate(g.zone.GetKind())
+	case "name":
+		return t.evaluate(g.zone.GetName())
+	case "region":
+		return t.evaluate(g.zone.GetRegion())
+	case "selfLink":
+		return t.evaluate(g.zone.GetSelfLink())
+	case "status":
+		return t.evaluate(g.zone.GetStatus())
+	case "supportsPzs":
+		return t.evaluate(strconv.FormatBool(g.zone.GetSupportsPzs()))
+	default:
+		return false, fmt.Errorf("unknown key %v", t.Key)
+	}
+}
+
+// FilterZones filters the given zones according to the gcpFilter
+// Notes:
+//  1. The query shall comply with https://cloud.google.com/compute/docs/reference/rest/v1/zones/list
+func FilterZones(zones []*computepb.Zone, gcpFilter string) ([]*computepb.Zone, error) {
+	return filterResources(zones, gcpFilter, func(zone *computepb.Zone) gcpZone {
+		return gcpZone{zone: zone}
+	})
+}
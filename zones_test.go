@@ -0,0 +1,110 @@
+// gcloudfilter
+//
+// Copyright 2023 Kosmas Valianos
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloudfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+)
+
+type zonesArray []*computepb.Zone
+
+func (z zonesArray) String() string {
+	if len(z) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(128)
+	for _, zone := range z {
+		sb.WriteString(zone.GetName() + " ")
+	}
+	return sb.String()[:sb.Len()-1]
+}
+
+func TestFilterZones(t *testing.T) {
+	zones := zonesArray{
+		{
+			Name:                  toStringPtr("europe-west1-b"),
+			Region:                toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/europe-west1"),
+			Status:                toStringPtr("UP"),
+			AvailableCpuPlatforms: []string{"Intel Broadwell", "Intel Cascade Lake", "AMD Milan"},
+			CreationTimestamp:     toStringPtr("1969-12-31T16:00:00.000-08:00"),
+		},
+		{
+			Name:                  toStringPtr("us-central1-f"),
+			Region:                toStringPtr("https://www.googleapis.com/compute/v1/projects/appgate-dev/regions/us-central1"),
+			Status:                toStringPtr("UP"),
+			AvailableCpuPlatforms: []string{"Intel Broadwell"},
+			Deprecated: &computepb.DeprecationStatus{
+				State:    toStringPtr("DEPRECATED"),
+				Obsolete: toStringPtr("2024-06-01T00:00:00.000-07:00"),
+			},
+		},
+	}
+	type args struct {
+		gcpFilter string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantZones zonesArray
+		wantErr   bool
+	}{
+		{
+			name: "Region and CPU platforms",
+			args: args{
+				gcpFilter: `region:*/europe-* AND availableCpuPlatforms:"AMD Milan"`,
+			},
+			wantZones: zonesArray{
+				zones[0],
+			},
+		},
+		{
+			name: "Deprecated zones",
+			args: args{
+				gcpFilter: `status=UP AND deprecated.state=DEPRECATED AND deprecated.obsolete<"2024-07-01T00:00:00Z"`,
+			},
+			wantZones: zonesArray{
+				zones[1],
+			},
+		},
+		{
+			name: "Unknown deprecated key",
+			args: args{
+				gcpFilter: `deprecated.foo:bar`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotZones, err := FilterZones(zones, tt.args.gcpFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterZones() error: \"%v\". wantErr: %v", err, tt.wantErr)
+				return
+			}
+			gotZonesArray := zonesArray(gotZones)
+			if !reflect.DeepEqual(gotZonesArray, tt.wantZones) {
+				t.Errorf("FilterZones(): \"%v\". want: \"%v\"", gotZonesArray, tt.wantZones)
+			}
+			t.Log(gotZonesArray)
+		})
+	}
+}